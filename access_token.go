@@ -0,0 +1,101 @@
+package jwt
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// AccessTokenType is a "typ" header value for JWT access tokens.
+const AccessTokenType = "at+jwt"
+
+// AccessTokenClaims represents claims for JWT access token.
+// See: https://tools.ietf.org/html/rfc9068#section-2.2
+//
+type AccessTokenClaims struct {
+	StandardClaims
+
+	// ClientID claim identifies the OAuth 2.0 client that requested the token.
+	ClientID string `json:"client_id,omitempty"`
+
+	// Scope claim is a space-separated list of scopes.
+	Scope string `json:"scope,omitempty"`
+
+	// AuthTime claim is the time when the end-user authentication occurred.
+	AuthTime *NumericDate `json:"auth_time,omitempty"`
+
+	// AuthContextClassRef claim is the authentication context class reference.
+	AuthContextClassRef string `json:"acr,omitempty"`
+
+	// AuthMethodsRef claim is a list of the authentication methods used.
+	AuthMethodsRef []string `json:"amr,omitempty"`
+
+	// Groups, Roles and Entitlements claims are the end-user attributes.
+	// See: https://tools.ietf.org/html/rfc9068#section-2.2.3.1
+	Groups       []string `json:"groups,omitempty"`
+	Roles        []string `json:"roles,omitempty"`
+	Entitlements []string `json:"entitlements,omitempty"`
+
+	// Confirmation claim binds the token to a key.
+	Confirmation *Confirmation `json:"cnf,omitempty"`
+}
+
+// Scopes returns the scopes from the scope claim.
+func (c AccessTokenClaims) Scopes() []string {
+	return strings.Fields(c.Scope)
+}
+
+// HasScope reports whether token has a given scope.
+func (c AccessTokenClaims) HasScope(scope string) bool {
+	for _, s := range strings.Fields(c.Scope) {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// AccessTokenValidator validates JWT access tokens.
+// See: https://tools.ietf.org/html/rfc9068#section-4
+//
+type AccessTokenValidator struct {
+	issuer   string
+	audience string
+}
+
+// NewAccessTokenValidator returns a new validator for the tokens
+// issued by the given issuer for the given resource server (audience).
+func NewAccessTokenValidator(issuer, audience string) *AccessTokenValidator {
+	return &AccessTokenValidator{
+		issuer:   issuer,
+		audience: audience,
+	}
+}
+
+// Validate checks that the token conforms to the profile and returns its claims.
+// Token signature isn't checked, use ParseAndVerify to obtain the token.
+func (v *AccessTokenValidator) Validate(token *Token, now time.Time) (*AccessTokenClaims, error) {
+	if !isMediaType(token.Header().Type, AccessTokenType) {
+		return nil, ErrInvalidType
+	}
+
+	var claims AccessTokenClaims
+	if err := json.Unmarshal(token.RawClaims(), &claims); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case claims.Issuer == "", claims.Subject == "", claims.ClientID == "", claims.ID == "",
+		len(claims.Audience) == 0, claims.ExpiresAt == nil, claims.IssuedAt == nil:
+		return nil, ErrMissingClaim
+	case !claims.IsIssuer(v.issuer):
+		return nil, ErrInvalidIssuer
+	case !claims.IsForAudience(v.audience):
+		return nil, ErrInvalidAudience
+	}
+
+	if err := claims.validateAt(now); err != nil {
+		return nil, err
+	}
+	return &claims, nil
+}
@@ -0,0 +1,90 @@
+package jwt
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAccessTokenScopes(t *testing.T) {
+	claims := AccessTokenClaims{Scope: "openid  profile email"}
+
+	if got := claims.Scopes(); len(got) != 3 {
+		t.Errorf("want 3 scopes, got %#v", got)
+	}
+	if !claims.HasScope("profile") {
+		t.Error("must have scope")
+	}
+	if claims.HasScope("prof") {
+		t.Error("must not have scope")
+	}
+}
+
+func TestAccessTokenValidator(t *testing.T) {
+	now := time.Now()
+	signer := mustSigner(NewSignerHS(HS256, []byte("access-token-key-0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJK")))
+	validator := NewAccessTokenValidator("https://as.example.com", "https://rs.example.com")
+
+	validClaims := func() *AccessTokenClaims {
+		return &AccessTokenClaims{
+			StandardClaims: StandardClaims{
+				ID:        "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk",
+				Audience:  Audience{"https://rs.example.com"},
+				Issuer:    "https://as.example.com",
+				Subject:   "5ba552d67",
+				ExpiresAt: NewNumericDate(now.Add(time.Hour)),
+				IssuedAt:  NewNumericDate(now.Add(-time.Minute)),
+			},
+			ClientID: "s6BhdRkqt3",
+			Scope:    "openid profile reademail",
+		}
+	}
+
+	f := func(typ string, claims *AccessTokenClaims, want error) {
+		t.Helper()
+
+		token, err := NewBuilder(signer, WithType(typ)).Build(claims)
+		if err != nil {
+			t.Fatal(err)
+		}
+		token, err = Parse(token.Raw())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := validator.Validate(token, now)
+		if err != want {
+			t.Errorf("want %#v, got %#v", want, err)
+		}
+		if err == nil && !got.HasScope("reademail") {
+			t.Errorf("unexpected claims %#v", got)
+		}
+	}
+
+	f(AccessTokenType, validClaims(), nil)
+	f("application/at+JWT", validClaims(), nil)
+	f("JWT", validClaims(), ErrInvalidType)
+
+	c := validClaims()
+	c.ClientID = ""
+	f(AccessTokenType, c, ErrMissingClaim)
+
+	c = validClaims()
+	c.IssuedAt = nil
+	f(AccessTokenType, c, ErrMissingClaim)
+
+	c = validClaims()
+	c.Issuer = "https://evil.example.com"
+	f(AccessTokenType, c, ErrInvalidIssuer)
+
+	c = validClaims()
+	c.Audience = Audience{"https://other.example.com"}
+	f(AccessTokenType, c, ErrInvalidAudience)
+
+	c = validClaims()
+	c.ExpiresAt = NewNumericDate(now.Add(-time.Minute))
+	f(AccessTokenType, c, ErrTokenExpired)
+
+	c = validClaims()
+	c.NotBefore = NewNumericDate(now.Add(time.Minute))
+	f(AccessTokenType, c, ErrTokenNotYetValid)
+}
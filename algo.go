@@ -2,8 +2,15 @@ package jwt
 
 import (
 	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
 	_ "crypto/sha256" // to register a hash
 	_ "crypto/sha512" // to register a hash
+	"io"
+
+	"github.com/cloudflare/circl/sign/ed448"
 )
 
 // Signer is used to sign tokens.
@@ -19,6 +26,199 @@ type Verifier interface {
 	Verify(payload, signature []byte) error
 }
 
+// SignerOption is used to configure a Signer.
+type SignerOption func(*SignerOptions)
+
+// SignerOptions are the values set by SignerOption,
+// they're passed to the signer factory of a registered algorithm.
+type SignerOptions struct {
+	keyID          string
+	thumbprintHash crypto.Hash
+
+	// Policy is set by WithKeyPolicy, DefaultKeyPolicy by default.
+	Policy KeyPolicy
+
+	// Random is set by WithRandom, crypto/rand.Reader by default.
+	Random io.Reader
+
+	// Deterministic is set by WithDeterministicSignature.
+	Deterministic bool
+
+	// PSSSaltLength is set by WithPSSSaltLength, rsa.PSSSaltLengthEqualsHash by default.
+	PSSSaltLength int
+
+	// DeriveKey and KeyPurpose are set by WithDerivedKey.
+	DeriveKey  bool
+	KeyPurpose string
+}
+
+// WithKeyID sets key ID of the signer, it's used by Builder as "kid" header.
+func WithKeyID(kid string) SignerOption {
+	return func(o *SignerOptions) {
+		o.keyID = kid
+	}
+}
+
+// WithThumbprintKeyID sets key ID of the signer to the JWK thumbprint of its key.
+// Use JWK.ThumbprintKeyID with the same hash to get a matching "kid" for JWKS.
+// HS signers return ErrInvalidKey, thumbprint of a secret allows to check guessed secrets,
+// use WithKeyID for them.
+// See: https://tools.ietf.org/html/rfc7638#section-7
+func WithThumbprintKeyID(hash crypto.Hash) SignerOption {
+	return func(o *SignerOptions) {
+		o.thumbprintHash = hash
+	}
+}
+
+// WithKeyPolicy sets key policy of the signer, DefaultKeyPolicy is used by default.
+func WithKeyPolicy(policy KeyPolicy) SignerOption {
+	return func(o *SignerOptions) {
+		o.Policy = policy
+	}
+}
+
+// WithRandom sets the source of randomness for ES, PS, RS and ML-DSA signers, crypto/rand.Reader is used by default.
+// Note that since Go 1.26 crypto packages ignore it unless GODEBUG=cryptocustomrand=1 is set,
+// crypto/mldsa always ignores it.
+func WithRandom(random io.Reader) SignerOption {
+	return func(o *SignerOptions) {
+		o.Random = random
+	}
+}
+
+// WithDeterministicSignature makes ES and ML-DSA signers produce deterministic signatures.
+// Such signatures don't depend on the source of randomness, see RFC 6979 and FIPS 204.
+// ES signers require Go 1.24 or newer, otherwise NewSignerES returns ErrUnsupportedAlg.
+func WithDeterministicSignature() SignerOption {
+	return func(o *SignerOptions) {
+		o.Deterministic = true
+	}
+}
+
+// WithPSSSaltLength sets salt length of PS signers, rsa.PSSSaltLengthEqualsHash is used by default.
+// Salt length other than the hash size isn't allowed by RFC 7518.
+func WithPSSSaltLength(saltLength int) SignerOption {
+	return func(o *SignerOptions) {
+		o.PSSSaltLength = saltLength
+	}
+}
+
+// WithDerivedKey makes HS signers use a key derived from the given secret with HKDF,
+// purpose is used as HKDF info, so the same secret isn't reused across token types.
+// See: https://tools.ietf.org/html/rfc5869
+func WithDerivedKey(purpose string) SignerOption {
+	return func(o *SignerOptions) {
+		o.DeriveKey = true
+		o.KeyPurpose = purpose
+	}
+}
+
+func newSignerOptions(opts []SignerOption) *SignerOptions {
+	o := &SignerOptions{
+		Policy:        DefaultKeyPolicy,
+		Random:        rand.Reader,
+		PSSSaltLength: rsa.PSSSaltLengthEqualsHash,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// getKeyID returns key ID for the signer with a given key (public key or HMAC secret).
+func (o *SignerOptions) getKeyID(key interface{}) (string, error) {
+	if o.thumbprintHash == 0 {
+		return o.keyID, nil
+	}
+	jwk, err := NewJWK(key)
+	if err != nil {
+		return "", err
+	}
+	return jwk.ThumbprintKeyID(o.thumbprintHash)
+}
+
+// VerifierOption is used to configure a Verifier.
+type VerifierOption func(*VerifierOptions)
+
+// VerifierOptions are the values set by VerifierOption,
+// they're passed to the verifier factory of a registered algorithm.
+type VerifierOptions struct {
+	// Policy is set by WithVerifierKeyPolicy, DefaultKeyPolicy by default.
+	Policy KeyPolicy
+
+	// PSSSaltLength is set by WithVerifierPSSSaltLength, rsa.PSSSaltLengthEqualsHash by default.
+	PSSSaltLength int
+
+	// DeriveKey and KeyPurpose are set by WithVerifierDerivedKey.
+	DeriveKey  bool
+	KeyPurpose string
+}
+
+// WithVerifierKeyPolicy sets key policy of the verifier, DefaultKeyPolicy is used by default.
+func WithVerifierKeyPolicy(policy KeyPolicy) VerifierOption {
+	return func(o *VerifierOptions) {
+		o.Policy = policy
+	}
+}
+
+// WithVerifierPSSSaltLength sets salt length accepted by PS verifiers, rsa.PSSSaltLengthEqualsHash is used by default.
+// Use rsa.PSSSaltLengthAuto to accept signatures with any salt length from legacy peers.
+func WithVerifierPSSSaltLength(saltLength int) VerifierOption {
+	return func(o *VerifierOptions) {
+		o.PSSSaltLength = saltLength
+	}
+}
+
+// WithVerifierDerivedKey makes HS verifiers use a key derived from the given secret with HKDF,
+// it must be used with the same purpose as WithDerivedKey of the signer.
+func WithVerifierDerivedKey(purpose string) VerifierOption {
+	return func(o *VerifierOptions) {
+		o.DeriveKey = true
+		o.KeyPurpose = purpose
+	}
+}
+
+func newVerifierOptions(opts []VerifierOption) *VerifierOptions {
+	o := &VerifierOptions{
+		Policy:        DefaultKeyPolicy,
+		PSSSaltLength: rsa.PSSSaltLengthEqualsHash,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// keyIDSigner is implemented by signers with a key ID.
+type keyIDSigner interface {
+	KeyID() string
+}
+
+// digestVerifier is implemented by verifiers which verify a hash of the payload,
+// so the hash state can be reused by the caller.
+type digestVerifier interface {
+	hashFunc() crypto.Hash
+	verifyDigest(digest, signature []byte) error
+}
+
+// digestSigner is implemented by signers which sign a hash of the payload,
+// so Builder can hash the payload while it's encoded.
+type digestSigner interface {
+	hashFunc() crypto.Hash
+	signDigest(digest []byte) ([]byte, error)
+}
+
+// keyIDVerifier is implemented by verifiers which select a key by "kid" header,
+// so the caller can pass the key ID of the parsed header.
+type keyIDVerifier interface {
+	verifyKeyID(kid string, payload, signature []byte) error
+}
+
+// appendSigner is implemented by signers which can append a signature to a buffer.
+type appendSigner interface {
+	appendSign(dst, payload []byte) ([]byte, error)
+}
+
 // Algorithm for signing and verifying.
 type Algorithm string
 
@@ -43,6 +243,14 @@ const (
 	PS256 Algorithm = "PS256"
 	PS384 Algorithm = "PS384"
 	PS512 Algorithm = "PS512"
+
+	// ML-DSA algorithms require Go 1.27 or later, see NewSignerMLDSA.
+	MLDSA44 Algorithm = "ML-DSA-44"
+	MLDSA65 Algorithm = "ML-DSA-65"
+	MLDSA87 Algorithm = "ML-DSA-87"
+
+	// None is used for unsigned tokens, see NewSignerUnsafeNone.
+	None Algorithm = "none"
 )
 
 func hashPayload(hash crypto.Hash, payload []byte) ([]byte, error) {
@@ -55,3 +263,58 @@ func hashPayload(hash crypto.Hash, payload []byte) ([]byte, error) {
 	signed := hasher.Sum(nil)
 	return signed, nil
 }
+
+// NewSignerForKey returns a signer for the given algorithm and private key.
+// Supported keys are []byte for HS, *rsa.PrivateKey, *ecdsa.PrivateKey, ed25519.PrivateKey,
+// ed448.PrivateKey and keys of the registered algorithms.
+// For registered algorithms WithThumbprintKeyID requires a key which implements crypto.Signer.
+func NewSignerForKey(alg Algorithm, key crypto.PrivateKey, opts ...SignerOption) (Signer, error) {
+	if a, ok := lookupAlgorithm(alg); ok {
+		return a.newSigner(key, opts)
+	}
+
+	switch key := key.(type) {
+	case []byte:
+		return NewSignerHS(alg, key, opts...)
+	case *rsa.PrivateKey:
+		if _, ok := getHashRSA(alg); ok {
+			return NewSignerRS(alg, key, opts...)
+		}
+		return NewSignerPS(alg, key, opts...)
+	case *ecdsa.PrivateKey:
+		return NewSignerES(alg, key, opts...)
+	case ed25519.PrivateKey, ed448.PrivateKey:
+		if alg != EdDSA {
+			return nil, ErrUnsupportedAlg
+		}
+		return NewSignerEdDSA(key, opts...)
+	default:
+		return nil, ErrInvalidKey
+	}
+}
+
+// NewVerifierForKey returns a verifier for the given algorithm and public key.
+// Supported keys are *rsa.PublicKey, *ecdsa.PublicKey, ed25519.PublicKey, ed448.PublicKey
+// and keys of the registered algorithms.
+func NewVerifierForKey(alg Algorithm, key crypto.PublicKey, opts ...VerifierOption) (Verifier, error) {
+	if a, ok := lookupAlgorithm(alg); ok {
+		return a.newVerifier(key, opts)
+	}
+
+	switch key := key.(type) {
+	case *rsa.PublicKey:
+		if _, ok := getHashRSA(alg); ok {
+			return NewVerifierRS(alg, key, opts...)
+		}
+		return NewVerifierPS(alg, key, opts...)
+	case *ecdsa.PublicKey:
+		return NewVerifierES(alg, key, opts...)
+	case ed25519.PublicKey, ed448.PublicKey:
+		if alg != EdDSA {
+			return nil, ErrUnsupportedAlg
+		}
+		return NewVerifierEdDSA(key, opts...)
+	default:
+		return nil, ErrInvalidKey
+	}
+}
@@ -1,22 +1,65 @@
 package jwt
 
 import (
+	"crypto"
 	"crypto/ed25519"
+
+	"github.com/cloudflare/circl/sign/ed448"
 )
 
-// NewSignerEdDSA returns a new ed25519-based signer.
-func NewSignerEdDSA(key ed25519.PrivateKey) (Signer, error) {
+// NewSignerEdDSA returns a new EdDSA signer, the curve is selected by the key:
+// ed25519.PrivateKey (or []byte) for Ed25519 and ed448.PrivateKey
+// from github.com/cloudflare/circl/sign/ed448 for Ed448.
+// See: https://tools.ietf.org/html/rfc8037#section-3.1
+//
+func NewSignerEdDSA(key crypto.PrivateKey, opts ...SignerOption) (Signer, error) {
+	switch key := key.(type) {
+	case ed25519.PrivateKey:
+		return newSignerEd25519(key, opts)
+	case []byte:
+		return newSignerEd25519(key, opts)
+	case ed448.PrivateKey:
+		return newSignerEd448(key, opts)
+	default:
+		return nil, ErrInvalidKey
+	}
+}
+
+// NewVerifierEdDSA returns a new EdDSA verifier, the curve is selected by the key:
+// ed25519.PublicKey (or []byte) for Ed25519 and ed448.PublicKey
+// from github.com/cloudflare/circl/sign/ed448 for Ed448.
+// Options are accepted as by other verifiers, key policy has no restrictions for EdDSA keys.
+//
+func NewVerifierEdDSA(key crypto.PublicKey, opts ...VerifierOption) (Verifier, error) {
+	switch key := key.(type) {
+	case ed25519.PublicKey:
+		return newVerifierEd25519(key)
+	case []byte:
+		return newVerifierEd25519(key)
+	case ed448.PublicKey:
+		return newVerifierEd448(key)
+	default:
+		return nil, ErrInvalidKey
+	}
+}
+
+func newSignerEd25519(key ed25519.PrivateKey, opts []SignerOption) (Signer, error) {
 	if len(key) == 0 || len(key) != ed25519.PrivateKeySize {
 		return nil, ErrInvalidKey
 	}
+	kid, err := newSignerOptions(opts).getKeyID(key.Public())
+	if err != nil {
+		return nil, err
+	}
 	return &edDSAAlg{
 		alg:        EdDSA,
+		publicKey:  key.Public().(ed25519.PublicKey),
 		privateKey: key,
+		kid:        kid,
 	}, nil
 }
 
-// NewVerifierEdDSA returns a new ed25519-based verifier.
-func NewVerifierEdDSA(key ed25519.PublicKey) (Verifier, error) {
+func newVerifierEd25519(key ed25519.PublicKey) (Verifier, error) {
 	if len(key) == 0 || len(key) != ed25519.PublicKeySize {
 		return nil, ErrInvalidKey
 	}
@@ -30,18 +73,30 @@ type edDSAAlg struct {
 	alg        Algorithm
 	publicKey  ed25519.PublicKey
 	privateKey ed25519.PrivateKey
+	kid        string
 }
 
 func (ed edDSAAlg) Algorithm() Algorithm {
 	return ed.alg
 }
 
+func (ed edDSAAlg) KeyID() string {
+	return ed.kid
+}
+
 func (ed edDSAAlg) SignSize() int {
 	return ed25519.SignatureSize
 }
 
 func (ed edDSAAlg) Sign(payload []byte) ([]byte, error) {
-	return ed25519.Sign(ed.privateKey, payload), nil
+	return ed.appendSign(nil, payload)
+}
+
+func (ed edDSAAlg) appendSign(dst, payload []byte) ([]byte, error) {
+	if len(ed.privateKey) != ed25519.PrivateKeySize {
+		return nil, ErrInvalidKey
+	}
+	return append(dst, ed25519.Sign(ed.privateKey, payload)...), nil
 }
 
 func (ed edDSAAlg) Verify(payload, signature []byte) error {
@@ -50,3 +105,63 @@ func (ed edDSAAlg) Verify(payload, signature []byte) error {
 	}
 	return nil
 }
+
+func newSignerEd448(key ed448.PrivateKey, opts []SignerOption) (Signer, error) {
+	if len(key) != ed448.PrivateKeySize {
+		return nil, ErrInvalidKey
+	}
+	kid, err := newSignerOptions(opts).getKeyID(key.Public())
+	if err != nil {
+		return nil, err
+	}
+	return &ed448Alg{
+		alg:        EdDSA,
+		publicKey:  key.Public().(ed448.PublicKey),
+		privateKey: key,
+		kid:        kid,
+	}, nil
+}
+
+func newVerifierEd448(key ed448.PublicKey) (Verifier, error) {
+	if len(key) != ed448.PublicKeySize {
+		return nil, ErrInvalidKey
+	}
+	return &ed448Alg{
+		alg:       EdDSA,
+		publicKey: key,
+	}, nil
+}
+
+type ed448Alg struct {
+	alg        Algorithm
+	publicKey  ed448.PublicKey
+	privateKey ed448.PrivateKey
+	kid        string
+}
+
+func (ed ed448Alg) Algorithm() Algorithm {
+	return ed.alg
+}
+
+func (ed ed448Alg) KeyID() string {
+	return ed.kid
+}
+
+func (ed ed448Alg) SignSize() int {
+	return ed448.SignatureSize
+}
+
+func (ed ed448Alg) Sign(payload []byte) ([]byte, error) {
+	if len(ed.privateKey) != ed448.PrivateKeySize {
+		return nil, ErrInvalidKey
+	}
+	// pure Ed448 with an empty context, as RFC 8037 requires
+	return ed448.Sign(ed.privateKey, payload, ""), nil
+}
+
+func (ed ed448Alg) Verify(payload, signature []byte) error {
+	if !ed448.Verify(ed.publicKey, payload, signature, "") {
+		return ErrInvalidSignature
+	}
+	return nil
+}
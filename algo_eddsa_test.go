@@ -1,8 +1,13 @@
 package jwt
 
 import (
+	"bytes"
 	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
 	"testing"
+
+	"github.com/cloudflare/circl/sign/ed448"
 )
 
 // example from RFC 8037, appendix A.1
@@ -67,6 +72,11 @@ func TestEdDSA(t *testing.T) {
 			TestField: "foo",
 		},
 	)
+	f(
+		mustSigner(NewSignerEdDSA(ed25519Private, WithKeyPolicy(InsecureKeyPolicy))),
+		mustVerifier(NewVerifierEdDSA(ed25519Public, WithVerifierKeyPolicy(InsecureKeyPolicy))),
+		&StandardClaims{},
+	)
 }
 
 func TestEdDSA_InvalidSignature(t *testing.T) {
@@ -99,3 +109,112 @@ func TestEdDSA_InvalidSignature(t *testing.T) {
 		},
 	)
 }
+
+// See: https://tools.ietf.org/html/rfc8032#section-7.4
+func TestEd448Vectors(t *testing.T) {
+	f := func(seed, public, message, signature string) {
+		t.Helper()
+
+		key := ed448.NewKeyFromSeed(mustHex(seed))
+		if got := key.Public().(ed448.PublicKey); !bytes.Equal(got, mustHex(public)) {
+			t.Errorf("want public key %s, got %x", public, got)
+		}
+
+		signer := mustSigner(NewSignerEdDSA(key))
+		got, err := signer.Sign(mustHex(message))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, mustHex(signature)) {
+			t.Errorf("want signature %s, got %x", signature, got)
+		}
+
+		verifier := mustVerifier(NewVerifierEdDSA(ed448.PublicKey(mustHex(public))))
+		if err := verifier.Verify(mustHex(message), got); err != nil {
+			t.Errorf("want nil, got %v", err)
+		}
+		got[0] ^= 1
+		if err := verifier.Verify(mustHex(message), got); err != ErrInvalidSignature {
+			t.Errorf("want %v, got %v", ErrInvalidSignature, err)
+		}
+	}
+
+	f(
+		"6c82a562cb808d10d632be89c8513ebf6c929f34ddfa8c9f63c9960ef6e348a3528c8a3fcc2f044e39a3fc5b94492f8f032e7549a20098f95b",
+		"5fd7449b59b461fd2ce787ec616ad46a1da1342485a70e1f8a0ea75d80e96778edf124769b46c7061bd6783df1e50f6cd1fa1abeafe8256180",
+		"",
+		"533a37f6bbe457251f023c0d88f976ae2dfb504a843e34d2074fd823d41a591f2b233f034f628281f2fd7a22ddd47d7828c59bd0a21bfd3980ff0d2028d4b18a9df63e006c5d1c2d345b925d8dc00b4104852db99ac5c7cdda8530a113a0f4dbb61149f05a7363268c71d95808ff2e652600",
+	)
+	f(
+		"c4eab05d357007c632f3dbb48489924d552b08fe0c353a0d4a1f00acda2c463afbea67c5e8d2877c5e3bc397a659949ef8021e954e0a12274e",
+		"43ba28f430cdff456ae531545f7ecd0ac834a55d9358c0372bfa0c6c6798c0866aea01eb00742802b8438ea4cb82169c235160627b4c3a9480",
+		"03",
+		"26b8f91727bd62897af15e41eb43c377efb9c610d48f2335cb0bd0087810f4352541b143c4b981b7e18f62de8ccdf633fc1bf037ab7cd779805e0dbcc0aae1cbcee1afb2e027df36bc04dcecbf154336c19f0af7e0a6472905e799f1953d2a0ff3348ab21aa4adafd1d234441cf807c03a00",
+	)
+}
+
+func TestEd448(t *testing.T) {
+	pub, priv, err := ed448.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherPub, _, err := ed448.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	token, err := NewBuilder(mustSigner(NewSignerEdDSA(priv))).Build(&customClaims{TestField: "foo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if alg := token.Header().Algorithm; alg != EdDSA {
+		t.Errorf("want %s, got %s", EdDSA, alg)
+	}
+
+	// verifier is selected by OKP curve of the JWK
+	jwk, err := NewJWK(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if jwk.Curve != CurveEd448 {
+		t.Errorf("want %s, got %s", CurveEd448, jwk.Curve)
+	}
+	key, err := jwk.PublicKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	verifier, err := NewVerifierForKey(EdDSA, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ParseAndVerify(token.Raw(), verifier); err != nil {
+		t.Errorf("want nil, got %v", err)
+	}
+
+	other := mustVerifier(NewVerifierEdDSA(otherPub, WithVerifierKeyPolicy(DefaultKeyPolicy)))
+	if _, err := ParseAndVerify(token.Raw(), other); err != ErrInvalidSignature {
+		t.Errorf("want %v, got %v", ErrInvalidSignature, err)
+	}
+	ed25519Verifier := mustVerifier(NewVerifierEdDSA(ed25519Public))
+	if _, err := ParseAndVerify(token.Raw(), ed25519Verifier); err != ErrInvalidSignature {
+		t.Errorf("want %v, got %v", ErrInvalidSignature, err)
+	}
+
+	if _, err := NewSignerEdDSA(priv[:ed448.SeedSize]); err != ErrInvalidKey {
+		t.Errorf("want %v, got %v", ErrInvalidKey, err)
+	}
+	if _, err := NewVerifierEdDSA(pub[1:]); err != ErrInvalidKey {
+		t.Errorf("want %v, got %v", ErrInvalidKey, err)
+	}
+	if _, err := NewVerifierForKey(ES256, pub); err != ErrUnsupportedAlg {
+		t.Errorf("want %v, got %v", ErrUnsupportedAlg, err)
+	}
+}
+
+func mustHex(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
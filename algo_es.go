@@ -3,12 +3,12 @@ package jwt
 import (
 	"crypto"
 	"crypto/ecdsa"
-	"crypto/rand"
+	"io"
 	"math/big"
 )
 
 // NewSignerES returns a new ECDSA-based signer.
-func NewSignerES(alg Algorithm, key *ecdsa.PrivateKey) (Signer, error) {
+func NewSignerES(alg Algorithm, key *ecdsa.PrivateKey, opts ...SignerOption) (Signer, error) {
 	if key == nil {
 		return nil, ErrInvalidKey
 	}
@@ -16,16 +16,31 @@ func NewSignerES(alg Algorithm, key *ecdsa.PrivateKey) (Signer, error) {
 	if !ok {
 		return nil, ErrUnsupportedAlg
 	}
+	o := newSignerOptions(opts)
+	if o.Deterministic && !hasRFC6979 {
+		return nil, ErrUnsupportedAlg
+	}
+	if err := o.Policy.checkCurve(alg, &key.PublicKey); err != nil {
+		return nil, err
+	}
+	kid, err := o.getKeyID(&key.PublicKey)
+	if err != nil {
+		return nil, err
+	}
 	return &esAlg{
-		alg:        alg,
-		hash:       hash,
-		privateKey: key,
-		signSize:   roundBytes(key.PublicKey.Params().BitSize) * 2,
+		alg:           alg,
+		hash:          hash,
+		publickey:     &key.PublicKey,
+		privateKey:    key,
+		signSize:      roundBytes(key.PublicKey.Params().BitSize) * 2,
+		kid:           kid,
+		random:        o.Random,
+		deterministic: o.Deterministic,
 	}, nil
 }
 
 // NewVerifierES returns a new ECDSA-based verifier.
-func NewVerifierES(alg Algorithm, key *ecdsa.PublicKey) (Verifier, error) {
+func NewVerifierES(alg Algorithm, key *ecdsa.PublicKey, opts ...VerifierOption) (Verifier, error) {
 	if key == nil {
 		return nil, ErrInvalidKey
 	}
@@ -33,6 +48,9 @@ func NewVerifierES(alg Algorithm, key *ecdsa.PublicKey) (Verifier, error) {
 	if !ok {
 		return nil, ErrUnsupportedAlg
 	}
+	if err := newVerifierOptions(opts).Policy.checkCurve(alg, key); err != nil {
+		return nil, err
+	}
 	return &esAlg{
 		alg:       alg,
 		hash:      hash,
@@ -55,17 +73,24 @@ func getParamsES(alg Algorithm) (crypto.Hash, bool) {
 }
 
 type esAlg struct {
-	alg        Algorithm
-	hash       crypto.Hash
-	publickey  *ecdsa.PublicKey
-	privateKey *ecdsa.PrivateKey
-	signSize   int
+	alg           Algorithm
+	hash          crypto.Hash
+	publickey     *ecdsa.PublicKey
+	privateKey    *ecdsa.PrivateKey
+	signSize      int
+	kid           string
+	random        io.Reader
+	deterministic bool
 }
 
 func (es esAlg) Algorithm() Algorithm {
 	return es.alg
 }
 
+func (es esAlg) KeyID() string {
+	return es.kid
+}
+
 func (es esAlg) SignSize() int {
 	return es.signSize
 }
@@ -75,9 +100,22 @@ func (es esAlg) Sign(payload []byte) ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
+	return es.signDigest(digest)
+}
 
-	r, s, errSign := ecdsa.Sign(rand.Reader, es.privateKey, digest)
-	if err != nil {
+func (es esAlg) signDigest(digest []byte) ([]byte, error) {
+	if es.privateKey == nil {
+		return nil, ErrInvalidKey
+	}
+
+	var r, s *big.Int
+	var errSign error
+	if es.deterministic {
+		r, s, errSign = signRFC6979(es.privateKey, es.hash, digest)
+	} else {
+		r, s, errSign = ecdsa.Sign(es.random, es.privateKey, digest)
+	}
+	if errSign != nil {
 		return nil, errSign
 	}
 
@@ -91,14 +129,21 @@ func (es esAlg) Sign(payload []byte) ([]byte, error) {
 }
 
 func (es esAlg) Verify(payload, signature []byte) error {
-	if len(signature) != es.SignSize() {
-		return ErrInvalidSignature
-	}
-
 	digest, err := hashPayload(es.hash, payload)
 	if err != nil {
 		return err
 	}
+	return es.verifyDigest(digest, signature)
+}
+
+func (es esAlg) hashFunc() crypto.Hash {
+	return es.hash
+}
+
+func (es esAlg) verifyDigest(digest, signature []byte) error {
+	if len(signature) != es.SignSize() {
+		return ErrInvalidSignature
+	}
 
 	pivot := es.SignSize() / 2
 	r := big.NewInt(0).SetBytes(signature[:pivot])
@@ -4,6 +4,7 @@ import (
 	"crypto/ecdsa"
 	"crypto/elliptic"
 	"crypto/rand"
+	"math/big"
 	"testing"
 )
 
@@ -143,3 +144,86 @@ func TestES384_WithInvalidSignature(t *testing.T) {
 		},
 	)
 }
+
+func TestESCurveMatrix(t *testing.T) {
+	keys := map[string]*ecdsa.PrivateKey{
+		"P-224": mustGenerateEC(elliptic.P224()),
+		"P-256": ecdsaPrivateKey256,
+		"P-384": ecdsaPrivateKey384,
+		"P-521": ecdsaPrivateKey521,
+	}
+	algCurves := map[Algorithm]string{
+		ES256: "P-256",
+		ES384: "P-384",
+		ES512: "P-521",
+	}
+
+	for alg, wantCurve := range algCurves {
+		for curve, key := range keys {
+			var want error
+			if curve != wantCurve {
+				want = ErrInvalidCurve
+			}
+
+			_, err := NewSignerES(alg, key)
+			if err != want {
+				t.Errorf("signer %s with %s: want %v, got %v", alg, curve, want, err)
+			}
+			_, err = NewVerifierES(alg, &key.PublicKey)
+			if err != want {
+				t.Errorf("verifier %s with %s: want %v, got %v", alg, curve, want, err)
+			}
+			_, err = NewVerifierForKey(alg, &key.PublicKey)
+			if err != want {
+				t.Errorf("verifier for key %s with %s: want %v, got %v", alg, curve, want, err)
+			}
+
+			// mismatch is allowed by the policy, public key is still checked
+			insecure := WithVerifierKeyPolicy(InsecureKeyPolicy)
+			if _, err := NewVerifierES(alg, &key.PublicKey, insecure); err != nil {
+				t.Errorf("insecure verifier %s with %s: want nil, got %v", alg, curve, err)
+			}
+			offCurve := &ecdsa.PublicKey{
+				Curve: key.Curve,
+				X:     key.X,
+				Y:     new(big.Int).Add(key.Y, big.NewInt(1)),
+			}
+			if _, err := NewVerifierES(alg, offCurve, insecure); err != ErrInvalidCurve {
+				t.Errorf("insecure verifier %s with %s off curve: want %v, got %v", alg, curve, ErrInvalidCurve, err)
+			}
+		}
+	}
+}
+
+func TestESInvalidPublicKey(t *testing.T) {
+	f := func(key *ecdsa.PublicKey, want error) {
+		t.Helper()
+
+		if _, err := NewVerifierES(ES256, key); err != want {
+			t.Errorf("want %v, got %v", want, err)
+		}
+	}
+
+	x, y := ecdsaPublicKey256.X, ecdsaPublicKey256.Y
+
+	f(&ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}, nil)
+	f(&ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: new(big.Int).Neg(y)}, ErrInvalidCurve)
+	f(&ecdsa.PublicKey{Curve: elliptic.P256(), X: big.NewInt(0), Y: big.NewInt(0)}, ErrInvalidCurve)
+	f(&ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).Add(x, elliptic.P256().Params().P), Y: y}, ErrInvalidCurve)
+	f(&ecdsa.PublicKey{Curve: elliptic.P256(), X: x}, ErrInvalidKey)
+	f(&ecdsa.PublicKey{X: x, Y: y}, ErrInvalidKey)
+
+	offCurve := *ecdsaPrivateKey256
+	offCurve.PublicKey = ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: new(big.Int).Add(y, big.NewInt(1))}
+	if _, err := NewSignerES(ES256, &offCurve); err != ErrInvalidCurve {
+		t.Errorf("want %v, got %v", ErrInvalidCurve, err)
+	}
+}
+
+func mustGenerateEC(curve elliptic.Curve) *ecdsa.PrivateKey {
+	key, err := ecdsa.GenerateKey(curve, rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+	return key
+}
@@ -1,14 +1,18 @@
 package jwt
 
 import (
+	"bytes"
 	"crypto"
 	"crypto/hmac"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
 	"hash"
 	"sync"
 )
 
 // NewSignerHS returns a new HMAC-based signer.
-func NewSignerHS(alg Algorithm, key []byte) (Signer, error) {
+func NewSignerHS(alg Algorithm, key []byte, opts ...SignerOption) (Signer, error) {
 	if len(key) == 0 {
 		return nil, ErrInvalidKey
 	}
@@ -16,10 +20,26 @@ func NewSignerHS(alg Algorithm, key []byte) (Signer, error) {
 	if !ok {
 		return nil, ErrUnsupportedAlg
 	}
+	o := newSignerOptions(opts)
+	if o.thumbprintHash != 0 {
+		// thumbprint of a secret is its hash, it mustn't be published as "kid"
+		return nil, ErrInvalidKey
+	}
+	if err := o.Policy.checkHMAC(hash, key); err != nil {
+		return nil, err
+	}
+	if o.DeriveKey {
+		key = deriveKeyHS(hash, key, o.KeyPurpose)
+	}
+	kid, err := o.getKeyID(key)
+	if err != nil {
+		return nil, err
+	}
 	return &hsAlg{
 		alg:  alg,
 		hash: hash,
 		key:  key,
+		kid:  kid,
 		hashPool: &sync.Pool{New: func() interface{} {
 			return hmac.New(hash.New, key)
 		}},
@@ -27,7 +47,7 @@ func NewSignerHS(alg Algorithm, key []byte) (Signer, error) {
 }
 
 // NewVerifierHS returns a new HMAC-based verifier.
-func NewVerifierHS(alg Algorithm, key []byte) (Verifier, error) {
+func NewVerifierHS(alg Algorithm, key []byte, opts ...VerifierOption) (Verifier, error) {
 	if len(key) == 0 {
 		return nil, ErrInvalidKey
 	}
@@ -35,6 +55,56 @@ func NewVerifierHS(alg Algorithm, key []byte) (Verifier, error) {
 	if !ok {
 		return nil, ErrUnsupportedAlg
 	}
+	return newVerifierHS(alg, hash, key, newVerifierOptions(opts))
+}
+
+// HSKey is a secret for NewVerifierHSKeys with an optional key ID.
+type HSKey struct {
+	KeyID string
+	Key   []byte
+}
+
+// NewVerifierHSKeys returns a new HMAC-based verifier which accepts any of the given secrets,
+// so old and new secrets are both accepted during rotation.
+// If token has "kid" header only secrets with the same or empty key ID are tried.
+// Each of the tried secrets is checked regardless of a match, so the verification time
+// doesn't depend on which of them matched, but it depends on how many of them "kid" selects.
+//
+func NewVerifierHSKeys(alg Algorithm, keys []HSKey, opts ...VerifierOption) (Verifier, error) {
+	if len(keys) == 0 {
+		return nil, ErrInvalidKey
+	}
+	hash, ok := getHashHMAC(alg)
+	if !ok {
+		return nil, ErrUnsupportedAlg
+	}
+	o := newVerifierOptions(opts)
+
+	verifiers := make([]hsKeyVerifier, len(keys))
+	for i, key := range keys {
+		verifier, err := newVerifierHS(alg, hash, key.Key, o)
+		if err != nil {
+			return nil, err
+		}
+		verifiers[i] = hsKeyVerifier{kid: key.KeyID, alg: verifier}
+	}
+	return &hsKeysAlg{
+		alg:  alg,
+		hash: hash,
+		keys: verifiers,
+	}, nil
+}
+
+func newVerifierHS(alg Algorithm, hash crypto.Hash, key []byte, o *VerifierOptions) (*hsAlg, error) {
+	if len(key) == 0 {
+		return nil, ErrInvalidKey
+	}
+	if err := o.Policy.checkHMAC(hash, key); err != nil {
+		return nil, err
+	}
+	if o.DeriveKey {
+		key = deriveKeyHS(hash, key, o.KeyPurpose)
+	}
 	return &hsAlg{
 		alg:  alg,
 		hash: hash,
@@ -62,6 +132,7 @@ type hsAlg struct {
 	alg      Algorithm
 	hash     crypto.Hash
 	key      []byte
+	kid      string
 	hashPool *sync.Pool
 }
 
@@ -69,16 +140,20 @@ func (hs hsAlg) Algorithm() Algorithm {
 	return hs.alg
 }
 
+func (hs hsAlg) KeyID() string {
+	return hs.kid
+}
+
 func (hs hsAlg) SignSize() int {
 	return hs.hash.Size()
 }
 
 func (hs hsAlg) Sign(payload []byte) ([]byte, error) {
-	return hs.sign(payload)
+	return hs.appendSign(nil, payload)
 }
 
 func (hs hsAlg) Verify(payload, signature []byte) error {
-	digest, err := hs.sign(payload)
+	digest, err := hs.appendSign(nil, payload)
 	if err != nil {
 		return err
 	}
@@ -88,7 +163,7 @@ func (hs hsAlg) Verify(payload, signature []byte) error {
 	return nil
 }
 
-func (hs hsAlg) sign(payload []byte) ([]byte, error) {
+func (hs hsAlg) appendSign(dst, payload []byte) ([]byte, error) {
 	hasher := hs.hashPool.Get().(hash.Hash)
 	defer func() {
 		hasher.Reset()
@@ -99,5 +174,88 @@ func (hs hsAlg) sign(payload []byte) ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
-	return hasher.Sum(nil), nil
+	return hasher.Sum(dst), nil
+}
+
+type hsKeysAlg struct {
+	alg  Algorithm
+	hash crypto.Hash
+	keys []hsKeyVerifier
+}
+
+type hsKeyVerifier struct {
+	kid string
+	alg *hsAlg
+}
+
+func (hs hsKeysAlg) Algorithm() Algorithm {
+	return hs.alg
+}
+
+// SignSize returns size of the signatures accepted by the verifier.
+func (hs hsKeysAlg) SignSize() int {
+	return hs.hash.Size()
+}
+
+func (hs hsKeysAlg) Verify(payload, signature []byte) error {
+	kid, err := payloadKeyID(payload)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	return hs.verifyKeyID(kid, payload, signature)
+}
+
+// verifyKeyID is used by ParseAndVerify with "kid" of the parsed header.
+func (hs hsKeysAlg) verifyKeyID(kid string, payload, signature []byte) error {
+	var buf [64]byte
+	valid, tried := 0, 0
+	for _, key := range hs.keys {
+		if kid != "" && key.kid != "" && key.kid != kid {
+			continue
+		}
+		digest, err := key.alg.appendSign(buf[:0], payload)
+		if err != nil {
+			return err
+		}
+		valid |= subtle.ConstantTimeCompare(signature, digest)
+		tried++
+	}
+	if tried == 0 || valid != 1 {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// payloadKeyID returns "kid" header of the token payload.
+// Common headers are decoded on the stack by scanHeader.
+func payloadKeyID(payload []byte) (string, error) {
+	dot := bytes.IndexByte(payload, '.')
+	if dot < 0 {
+		return "", ErrInvalidFormat
+	}
+
+	var buf [256]byte
+	data := buf[:]
+	if n := base64.RawURLEncoding.DecodedLen(dot); n > len(buf) {
+		data = make([]byte, n)
+	}
+	n, err := base64.RawURLEncoding.Decode(data, payload[:dot])
+	if err != nil {
+		return "", ErrInvalidFormat
+	}
+
+	var header, prev Header
+	if scanHeader(data[:n], &header, &prev) {
+		return header.KeyID, nil
+	}
+	// copied, so buf doesn't escape to heap
+	return unmarshalKeyID(append([]byte(nil), data[:n]...))
+}
+
+func unmarshalKeyID(data []byte) (string, error) {
+	var header Header
+	if err := json.Unmarshal(data, &header); err != nil {
+		return "", ErrInvalidFormat
+	}
+	return header.KeyID, nil
 }
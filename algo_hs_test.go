@@ -20,38 +20,38 @@ func TestHMAC(t *testing.T) {
 		}
 	}
 	f(
-		mustSigner(NewSignerHS(HS256, []byte("key1"))),
-		mustVerifier(NewVerifierHS(HS256, []byte("key1"))),
+		mustSigner(NewSignerHS(HS256, []byte("key1-0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVW"))),
+		mustVerifier(NewVerifierHS(HS256, []byte("key1-0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVW"))),
 		&StandardClaims{},
 	)
 	f(
-		mustSigner(NewSignerHS(HS384, []byte("key2"))),
-		mustVerifier(NewVerifierHS(HS384, []byte("key2"))),
+		mustSigner(NewSignerHS(HS384, []byte("key2-0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVW"))),
+		mustVerifier(NewVerifierHS(HS384, []byte("key2-0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVW"))),
 		&StandardClaims{},
 	)
 	f(
-		mustSigner(NewSignerHS(HS512, []byte("key3"))),
-		mustVerifier(NewVerifierHS(HS512, []byte("key3"))),
+		mustSigner(NewSignerHS(HS512, []byte("key3-0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVW"))),
+		mustVerifier(NewVerifierHS(HS512, []byte("key3-0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVW"))),
 		&StandardClaims{},
 	)
 
 	f(
-		mustSigner(NewSignerHS(HS256, []byte("key1"))),
-		mustVerifier(NewVerifierHS(HS256, []byte("key1"))),
+		mustSigner(NewSignerHS(HS256, []byte("key1-0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVW"))),
+		mustVerifier(NewVerifierHS(HS256, []byte("key1-0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVW"))),
 		&customClaims{
 			TestField: "foo",
 		},
 	)
 	f(
-		mustSigner(NewSignerHS(HS384, []byte("key2"))),
-		mustVerifier(NewVerifierHS(HS384, []byte("key2"))),
+		mustSigner(NewSignerHS(HS384, []byte("key2-0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVW"))),
+		mustVerifier(NewVerifierHS(HS384, []byte("key2-0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVW"))),
 		&customClaims{
 			TestField: "bar",
 		},
 	)
 	f(
-		mustSigner(NewSignerHS(HS512, []byte("key3"))),
-		mustVerifier(NewVerifierHS(HS512, []byte("key3"))),
+		mustSigner(NewSignerHS(HS512, []byte("key3-0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVW"))),
+		mustVerifier(NewVerifierHS(HS512, []byte("key3-0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVW"))),
 		&customClaims{
 			TestField: "baz",
 		},
@@ -74,40 +74,176 @@ func TestHMAC_InvalidSignature(t *testing.T) {
 		}
 	}
 	f(
-		mustSigner(NewSignerHS(HS256, []byte("key1"))),
-		mustVerifier(NewVerifierHS(HS256, []byte("1key"))),
+		mustSigner(NewSignerHS(HS256, []byte("key1-0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVW"))),
+		mustVerifier(NewVerifierHS(HS256, []byte("1key-0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVW"))),
 		&StandardClaims{},
 	)
 	f(
-		mustSigner(NewSignerHS(HS384, []byte("key2"))),
-		mustVerifier(NewVerifierHS(HS384, []byte("2key"))),
+		mustSigner(NewSignerHS(HS384, []byte("key2-0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVW"))),
+		mustVerifier(NewVerifierHS(HS384, []byte("2key-0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVW"))),
 		&StandardClaims{},
 	)
 	f(
-		mustSigner(NewSignerHS(HS512, []byte("key3"))),
-		mustVerifier(NewVerifierHS(HS512, []byte("3key"))),
+		mustSigner(NewSignerHS(HS512, []byte("key3-0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVW"))),
+		mustVerifier(NewVerifierHS(HS512, []byte("3key-0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVW"))),
 		&StandardClaims{},
 	)
 
 	f(
-		mustSigner(NewSignerHS(HS256, []byte("key1"))),
-		mustVerifier(NewVerifierHS(HS256, []byte("1key"))),
+		mustSigner(NewSignerHS(HS256, []byte("key1-0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVW"))),
+		mustVerifier(NewVerifierHS(HS256, []byte("1key-0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVW"))),
 		&customClaims{
 			TestField: "foo",
 		},
 	)
 	f(
-		mustSigner(NewSignerHS(HS384, []byte("key2"))),
-		mustVerifier(NewVerifierHS(HS384, []byte("2key"))),
+		mustSigner(NewSignerHS(HS384, []byte("key2-0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVW"))),
+		mustVerifier(NewVerifierHS(HS384, []byte("2key-0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVW"))),
 		&customClaims{
 			TestField: "bar",
 		},
 	)
 	f(
-		mustSigner(NewSignerHS(HS512, []byte("key3"))),
-		mustVerifier(NewVerifierHS(HS512, []byte("3key"))),
+		mustSigner(NewSignerHS(HS512, []byte("key3-0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVW"))),
+		mustVerifier(NewVerifierHS(HS512, []byte("3key-0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVW"))),
 		&customClaims{
 			TestField: "baz",
 		},
 	)
 }
+
+func TestHSKeys(t *testing.T) {
+	oldKey := []byte("old-0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWX")
+	newKey := []byte("new-0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWX")
+	otherKey := []byte("other-0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUV")
+
+	build := func(key []byte, opts ...SignerOption) *Token {
+		t.Helper()
+		token, err := NewBuilder(mustSigner(NewSignerHS(HS256, key, opts...))).Build(&StandardClaims{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return token
+	}
+	f := func(verifier Verifier, token *Token, want error) {
+		t.Helper()
+		if _, err := ParseAndVerify(token.Raw(), verifier); err != want {
+			t.Errorf("want %v, got %v", want, err)
+		}
+	}
+
+	// during rotation both secrets are accepted
+	rotation := mustVerifier(NewVerifierHSKeys(HS256, []HSKey{{Key: oldKey}, {Key: newKey}}))
+	f(rotation, build(oldKey), nil)
+	f(rotation, build(newKey), nil)
+	f(rotation, build(newKey, WithKeyID("new")), nil)
+	f(rotation, build(otherKey), ErrInvalidSignature)
+
+	// secrets are selected by kid
+	byKeyID := mustVerifier(NewVerifierHSKeys(HS256, []HSKey{{KeyID: "old", Key: oldKey}, {KeyID: "new", Key: newKey}}))
+	f(byKeyID, build(oldKey, WithKeyID("old")), nil)
+	f(byKeyID, build(newKey, WithKeyID("new")), nil)
+	f(byKeyID, build(newKey), nil)
+	f(byKeyID, build(newKey, WithKeyID("old")), ErrInvalidSignature)
+	f(byKeyID, build(newKey, WithKeyID("unknown")), ErrInvalidSignature)
+
+	// secret without a key ID is tried for any kid
+	mixed := mustVerifier(NewVerifierHSKeys(HS256, []HSKey{{KeyID: "old", Key: oldKey}, {Key: newKey}}))
+	f(mixed, build(newKey, WithKeyID("old")), nil)
+	f(mixed, build(newKey, WithKeyID("unknown")), nil)
+	f(mixed, build(oldKey, WithKeyID("unknown")), ErrInvalidSignature)
+
+	// kid of the payload is used by Verify, headers with escapes are decoded by encoding/json
+	token := build(newKey, WithKeyID("new"))
+	if err := byKeyID.Verify(token.Payload(), token.Signature()); err != nil {
+		t.Errorf("want nil, got %v", err)
+	}
+	escaped := b64EncodeToString([]byte(`{"alg":"HS256","kid":"\u006ew"}`)) + "." + b64EncodeToString([]byte(`{}`))
+	signature, _ := mustSigner(NewSignerHS(HS256, newKey)).Sign([]byte(escaped))
+	if err := byKeyID.Verify([]byte(escaped), signature); err != ErrInvalidSignature {
+		t.Errorf("want %v, got %v", ErrInvalidSignature, err)
+	}
+	escaped = b64EncodeToString([]byte(`{"alg":"HS256","kid":"n\u0065w"}`)) + "." + b64EncodeToString([]byte(`{}`))
+	signature, _ = mustSigner(NewSignerHS(HS256, newKey)).Sign([]byte(escaped))
+	if err := byKeyID.Verify([]byte(escaped), signature); err != nil {
+		t.Errorf("want nil, got %v", err)
+	}
+
+	if err := rotation.Verify([]byte("not a token"), nil); err != ErrInvalidSignature {
+		t.Errorf("want %v, got %v", ErrInvalidSignature, err)
+	}
+	hs512 := mustVerifier(NewVerifierHSKeys(HS512, []HSKey{{Key: oldKey}}))
+	f(hs512, build(oldKey), ErrAlgorithmMismatch)
+
+	verify := func(_ Verifier, err error) error { return err }
+	check := func(err error, want error) {
+		t.Helper()
+		if err != want {
+			t.Errorf("want %v, got %v", want, err)
+		}
+	}
+	check(verify(NewVerifierHSKeys(HS256, nil)), ErrInvalidKey)
+	check(verify(NewVerifierHSKeys(HS256, []HSKey{{Key: oldKey}, {Key: nil}})), ErrInvalidKey)
+	check(verify(NewVerifierHSKeys(HS256, []HSKey{{Key: oldKey}, {Key: []byte("short")}})), ErrWeakKey)
+	check(verify(NewVerifierHSKeys("xxx", []HSKey{{Key: oldKey}})), ErrUnsupportedAlg)
+}
+
+func TestHSKeysAllocs(t *testing.T) {
+	if raceEnabled {
+		t.Skip("race detector allocates")
+	}
+	key := []byte("key-0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWX")
+	verifier := mustVerifier(NewVerifierHSKeys(HS256, []HSKey{{KeyID: "kid", Key: key}}))
+	token, err := NewBuilder(mustSigner(NewSignerHS(HS256, key, WithKeyID("kid")))).Build(&StandardClaims{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := verifier.Verify(token.Payload(), token.Signature()); err != nil {
+		t.Fatal(err)
+	}
+
+	// header is decoded on the stack, only the key ID string is allocated
+	allocs := testing.AllocsPerRun(100, func() {
+		if kid, _ := payloadKeyID(token.Payload()); kid != "kid" {
+			t.Fatalf("want kid, got %#v", kid)
+		}
+	})
+	if allocs > 1 {
+		t.Errorf("want at most 1 alloc, got %v", allocs)
+	}
+}
+
+func TestHSDerivedKey(t *testing.T) {
+	secret := []byte("key-0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWX")
+
+	f := func(signer Signer, verifier Verifier, want error) {
+		t.Helper()
+
+		token, err := NewBuilder(signer).Build(&StandardClaims{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := ParseAndVerify(token.Raw(), verifier); err != want {
+			t.Errorf("want %v, got %v", want, err)
+		}
+	}
+
+	for _, alg := range []Algorithm{HS256, HS384, HS512} {
+		access := mustSigner(NewSignerHS(alg, secret, WithDerivedKey("access")))
+
+		f(access, mustVerifier(NewVerifierHS(alg, secret, WithVerifierDerivedKey("access"))), nil)
+		f(access, mustVerifier(NewVerifierHS(alg, secret, WithVerifierDerivedKey("refresh"))), ErrInvalidSignature)
+		f(access, mustVerifier(NewVerifierHS(alg, secret)), ErrInvalidSignature)
+		f(access, mustVerifier(NewVerifierHSKeys(alg, []HSKey{{Key: secret}}, WithVerifierDerivedKey("access"))), nil)
+
+		hash, _ := getHashHMAC(alg)
+		derived := hkdf(hash, secret, nil, []byte("access"), hash.Size())
+		f(access, mustVerifier(NewVerifierHS(alg, derived)), nil)
+	}
+
+	// master secret is checked by the key policy
+	if _, err := NewSignerHS(HS256, []byte("short"), WithDerivedKey("access")); err != ErrWeakKey {
+		t.Errorf("want %v, got %v", ErrWeakKey, err)
+	}
+}
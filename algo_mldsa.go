@@ -0,0 +1,24 @@
+package jwt
+
+// NewSignerMLDSA returns a new ML-DSA signer for the private key seed.
+// With Go 1.27 or later ML-DSA keys from crypto/mldsa are also supported
+// by NewSignerForKey, NewVerifierForKey and JWK ("AKP" key type),
+// ErrUnsupportedAlg is returned with older versions.
+// The source set by WithRandom is passed to crypto/mldsa, which ignores it for now.
+// See: https://datatracker.ietf.org/doc/html/draft-ietf-cose-dilithium
+//
+func NewSignerMLDSA(alg Algorithm, seed []byte, opts ...SignerOption) (Signer, error) {
+	return newSignerMLDSA(alg, seed, newSignerOptions(opts))
+}
+
+// NewVerifierMLDSA returns a new ML-DSA verifier for the encoded public key.
+// Options are accepted as by other verifiers, key policy has no restrictions for ML-DSA keys.
+func NewVerifierMLDSA(alg Algorithm, publicKey []byte, opts ...VerifierOption) (Verifier, error) {
+	return newVerifierMLDSA(alg, publicKey, newVerifierOptions(opts))
+}
+
+// GenerateMLDSAKey generates an ML-DSA key pair for the algorithm,
+// it returns the encoded public key and the private key seed.
+func GenerateMLDSAKey(alg Algorithm) (publicKey, seed []byte, err error) {
+	return generateMLDSAKey(alg)
+}
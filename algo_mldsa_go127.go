@@ -0,0 +1,192 @@
+//go:build go1.27
+// +build go1.27
+
+package jwt
+
+import (
+	"crypto"
+	"crypto/mldsa"
+	"io"
+)
+
+func init() {
+	for _, alg := range []Algorithm{MLDSA44, MLDSA65, MLDSA87} {
+		alg := alg
+		RegisterAlgorithm(AlgorithmInfo{
+			Algorithm: alg,
+			KeyType:   KeyTypeAKP,
+			NewSigner: func(key crypto.PrivateKey, opts *SignerOptions) (Signer, error) {
+				k, ok := key.(*mldsa.PrivateKey)
+				if !ok || k == nil {
+					return nil, ErrInvalidKey
+				}
+				// key ID is set by NewSignerForKey
+				return newMLDSAAlg(alg, k, opts)
+			},
+			NewVerifier: func(key crypto.PublicKey, opts *VerifierOptions) (Verifier, error) {
+				k, ok := key.(*mldsa.PublicKey)
+				if !ok || k == nil {
+					return nil, ErrInvalidKey
+				}
+				return newVerifierMLDSA(alg, k.Bytes(), opts)
+			},
+			PublicKey: func(jwk *JWK) (crypto.PublicKey, error) {
+				params, _ := getParamsMLDSA(alg)
+				pub, err := b64Decode(jwk.Pub)
+				if err != nil || jwk.Algorithm != alg {
+					return nil, ErrInvalidKey
+				}
+				key, err := mldsa.NewPublicKey(params, pub)
+				if err != nil {
+					return nil, ErrInvalidKey
+				}
+				return key, nil
+			},
+			NewJWK: func(key crypto.PublicKey) (*JWK, error) {
+				k, ok := key.(*mldsa.PublicKey)
+				if !ok || k == nil || getAlgorithmMLDSA(k.Parameters()) != alg {
+					return nil, ErrInvalidKey
+				}
+				return &JWK{
+					KeyType:   KeyTypeAKP,
+					Algorithm: alg,
+					Pub:       b64EncodeToString(k.Bytes()),
+				}, nil
+			},
+			Thumbprint: func(jwk *JWK) ([]byte, error) {
+				if jwk.Pub == "" {
+					return nil, ErrInvalidKey
+				}
+				return []byte(`{"alg":"` + string(alg) + `","kty":"AKP","pub":"` + jwk.Pub + `"}`), nil
+			},
+		})
+	}
+}
+
+func newSignerMLDSA(alg Algorithm, seed []byte, o *SignerOptions) (Signer, error) {
+	params, ok := getParamsMLDSA(alg)
+	if !ok {
+		return nil, ErrUnsupportedAlg
+	}
+	key, err := mldsa.NewPrivateKey(params, seed)
+	if err != nil {
+		return nil, ErrInvalidKey
+	}
+	return newMLDSAAlg(alg, key, o)
+}
+
+func newVerifierMLDSA(alg Algorithm, publicKey []byte, o *VerifierOptions) (Verifier, error) {
+	params, ok := getParamsMLDSA(alg)
+	if !ok {
+		return nil, ErrUnsupportedAlg
+	}
+	key, err := mldsa.NewPublicKey(params, publicKey)
+	if err != nil {
+		return nil, ErrInvalidKey
+	}
+	return &mldsaAlg{
+		alg:       alg,
+		signSize:  params.SignatureSize(),
+		publicKey: key,
+	}, nil
+}
+
+func generateMLDSAKey(alg Algorithm) (publicKey, seed []byte, err error) {
+	params, ok := getParamsMLDSA(alg)
+	if !ok {
+		return nil, nil, ErrUnsupportedAlg
+	}
+	key, err := mldsa.GenerateKey(params)
+	if err != nil {
+		return nil, nil, err
+	}
+	return key.PublicKey().Bytes(), key.Bytes(), nil
+}
+
+func newMLDSAAlg(alg Algorithm, key *mldsa.PrivateKey, o *SignerOptions) (Signer, error) {
+	params := key.PublicKey().Parameters()
+	if getAlgorithmMLDSA(params) != alg {
+		return nil, ErrInvalidKey
+	}
+	kid, err := o.getKeyID(key.PublicKey())
+	if err != nil {
+		return nil, err
+	}
+	return &mldsaAlg{
+		alg:           alg,
+		signSize:      params.SignatureSize(),
+		publicKey:     key.PublicKey(),
+		privateKey:    key,
+		kid:           kid,
+		random:        o.Random,
+		deterministic: o.Deterministic,
+	}, nil
+}
+
+func getParamsMLDSA(alg Algorithm) (mldsa.Parameters, bool) {
+	switch alg {
+	case MLDSA44:
+		return mldsa.MLDSA44(), true
+	case MLDSA65:
+		return mldsa.MLDSA65(), true
+	case MLDSA87:
+		return mldsa.MLDSA87(), true
+	default:
+		return mldsa.Parameters{}, false
+	}
+}
+
+func getAlgorithmMLDSA(params mldsa.Parameters) Algorithm {
+	switch params {
+	case mldsa.MLDSA44():
+		return MLDSA44
+	case mldsa.MLDSA65():
+		return MLDSA65
+	case mldsa.MLDSA87():
+		return MLDSA87
+	default:
+		return ""
+	}
+}
+
+type mldsaAlg struct {
+	alg           Algorithm
+	signSize      int
+	publicKey     *mldsa.PublicKey
+	privateKey    *mldsa.PrivateKey
+	kid           string
+	random        io.Reader
+	deterministic bool
+}
+
+func (ml mldsaAlg) Algorithm() Algorithm {
+	return ml.alg
+}
+
+func (ml mldsaAlg) KeyID() string {
+	return ml.kid
+}
+
+func (ml mldsaAlg) SignSize() int {
+	return ml.signSize
+}
+
+func (ml mldsaAlg) Sign(payload []byte) ([]byte, error) {
+	if ml.privateKey == nil {
+		return nil, ErrInvalidKey
+	}
+	if ml.deterministic {
+		return ml.privateKey.SignDeterministic(payload, nil)
+	}
+	return ml.privateKey.Sign(ml.random, payload, nil)
+}
+
+func (ml mldsaAlg) Verify(payload, signature []byte) error {
+	if len(signature) != ml.signSize {
+		return ErrInvalidSignature
+	}
+	if err := mldsa.Verify(ml.publicKey, payload, signature, nil); err != nil {
+		return ErrInvalidSignature
+	}
+	return nil
+}
@@ -0,0 +1,16 @@
+//go:build !go1.27
+// +build !go1.27
+
+package jwt
+
+func newSignerMLDSA(alg Algorithm, seed []byte, o *SignerOptions) (Signer, error) {
+	return nil, ErrUnsupportedAlg
+}
+
+func newVerifierMLDSA(alg Algorithm, publicKey []byte, o *VerifierOptions) (Verifier, error) {
+	return nil, ErrUnsupportedAlg
+}
+
+func generateMLDSAKey(alg Algorithm) (publicKey, seed []byte, err error) {
+	return nil, nil, ErrUnsupportedAlg
+}
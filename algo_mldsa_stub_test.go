@@ -0,0 +1,22 @@
+//go:build !go1.27
+// +build !go1.27
+
+package jwt
+
+import (
+	"testing"
+)
+
+func TestMLDSAUnsupported(t *testing.T) {
+	seed := make([]byte, 32)
+
+	if _, err := NewSignerMLDSA(MLDSA44, seed); err != ErrUnsupportedAlg {
+		t.Errorf("want %v, got %v", ErrUnsupportedAlg, err)
+	}
+	if _, err := NewVerifierMLDSA(MLDSA44, make([]byte, 1312)); err != ErrUnsupportedAlg {
+		t.Errorf("want %v, got %v", ErrUnsupportedAlg, err)
+	}
+	if _, _, err := GenerateMLDSAKey(MLDSA44); err != ErrUnsupportedAlg {
+		t.Errorf("want %v, got %v", ErrUnsupportedAlg, err)
+	}
+}
@@ -0,0 +1,203 @@
+//go:build go1.27
+// +build go1.27
+
+package jwt
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/mldsa"
+	"strings"
+	"testing"
+)
+
+func TestMLDSA(t *testing.T) {
+	f := func(alg Algorithm, signSize int) {
+		t.Helper()
+
+		pub, seed, err := GenerateMLDSAKey(alg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		signer := mustSigner(NewSignerMLDSA(alg, seed))
+		verifier := mustVerifier(NewVerifierMLDSA(alg, pub))
+		if size := signer.SignSize(); size != signSize {
+			t.Errorf("%s: want sign size %d, got %d", alg, signSize, size)
+		}
+
+		// signature is much larger than for other algorithms
+		builder := NewBuilder(signer)
+		claims := &StandardClaims{ID: "just an id"}
+		token, err := builder.Build(claims)
+		if err != nil {
+			t.Fatal(err)
+		}
+		raw, err := builder.AppendBuild(make([]byte, 0, 16), claims)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, raw := range [][]byte{token.Raw(), raw} {
+			encoded := raw[bytes.LastIndexByte(raw, '.')+1:]
+			if len(encoded) != b64EncodedLen(signSize) {
+				t.Errorf("%s: want encoded signature of %d bytes, got %d", alg, b64EncodedLen(signSize), len(encoded))
+			}
+			parsed, err := ParseAndVerify(raw, verifier)
+			if err != nil {
+				t.Fatalf("%s: %v", alg, err)
+			}
+			if parsed.Header().Algorithm != alg {
+				t.Errorf("want %s, got %s", alg, parsed.Header().Algorithm)
+			}
+		}
+
+		signature := token.Signature()
+		if err := verifier.Verify(token.Payload(), signature[:len(signature)-1]); err != ErrInvalidSignature {
+			t.Errorf("%s: want %v, got %v", alg, ErrInvalidSignature, err)
+		}
+		signature[0] ^= 1
+		if err := verifier.Verify(token.Payload(), signature); err != ErrInvalidSignature {
+			t.Errorf("%s: want %v, got %v", alg, ErrInvalidSignature, err)
+		}
+	}
+
+	f(MLDSA44, mldsa.MLDSA44SignatureSize)
+	f(MLDSA65, mldsa.MLDSA65SignatureSize)
+	f(MLDSA87, mldsa.MLDSA87SignatureSize)
+}
+
+func TestMLDSADeterministic(t *testing.T) {
+	_, seed, err := GenerateMLDSAKey(MLDSA44)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	deterministic := mustSigner(NewSignerMLDSA(MLDSA44, seed, WithDeterministicSignature()))
+	sign1, _ := deterministic.Sign([]byte("header.payload"))
+	sign2, _ := deterministic.Sign([]byte("header.payload"))
+	if !bytes.Equal(sign1, sign2) {
+		t.Error("want same signatures")
+	}
+
+	hedged := mustSigner(NewSignerMLDSA(MLDSA44, seed))
+	sign1, _ = hedged.Sign([]byte("header.payload"))
+	sign2, _ = hedged.Sign([]byte("header.payload"))
+	if bytes.Equal(sign1, sign2) {
+		t.Error("want different signatures")
+	}
+}
+
+func TestMLDSASignerOptions(t *testing.T) {
+	key, err := mldsa.GenerateKey(mldsa.MLDSA65())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signer, err := NewSignerForKey(MLDSA65, key, WithDeterministicSignature(), WithKeyID("kid"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sign1, _ := signer.Sign([]byte("header.payload"))
+	sign2, _ := signer.Sign([]byte("header.payload"))
+	if !bytes.Equal(sign1, sign2) {
+		t.Error("want same signatures")
+	}
+	if kid := signer.(keyIDSigner).KeyID(); kid != "kid" {
+		t.Errorf("want kid, got %s", kid)
+	}
+
+	// verifier options are accepted, as by other verifiers
+	opt := WithVerifierKeyPolicy(KeyPolicy{MinRSAKeySize: 4096})
+	verifier := mustVerifier(NewVerifierMLDSA(MLDSA65, key.PublicKey().Bytes(), opt))
+	if err := verifier.Verify([]byte("header.payload"), sign1); err != nil {
+		t.Error(err)
+	}
+	verifier = mustVerifier(NewVerifierForKey(MLDSA65, key.PublicKey(), opt))
+	if err := verifier.Verify([]byte("header.payload"), sign1); err != nil {
+		t.Error(err)
+	}
+
+	// crypto/mldsa ignores the reader, but it's passed through
+	random := errReader{}
+	signer, err = NewSignerForKey(MLDSA65, key, WithRandom(random))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := signer.(*mldsaAlg).random; got != random {
+		t.Errorf("want %#v, got %#v", random, got)
+	}
+}
+
+func TestMLDSAKeys(t *testing.T) {
+	key, err := mldsa.GenerateKey(mldsa.MLDSA65())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signer, err := NewSignerForKey(MLDSA65, key, WithThumbprintKeyID(crypto.SHA256))
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, err := NewBuilder(signer).Build(&StandardClaims{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	jwk, err := NewJWK(key.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if jwk.KeyType != KeyTypeAKP || jwk.Algorithm != MLDSA65 || jwk.Pub == "" {
+		t.Errorf("want AKP JWK, got %#v", jwk)
+	}
+	thumbprint, err := jwk.Thumbprint(crypto.SHA256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	members := `{"alg":"ML-DSA-65","kty":"AKP","pub":"` + b64EncodeToString(key.PublicKey().Bytes()) + `"}`
+	want, _ := hashPayload(crypto.SHA256, []byte(members))
+	if !bytes.Equal(thumbprint, want) {
+		t.Errorf("want thumbprint %x, got %x", want, thumbprint)
+	}
+	if kid := token.Header().KeyID; kid != b64EncodeToString(want) {
+		t.Errorf("want kid %s, got %s", b64EncodeToString(want), kid)
+	}
+
+	publicKey, err := jwk.PublicKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	verifier, err := NewVerifierForKey(MLDSA65, publicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ParseAndVerify(token.Raw(), verifier); err != nil {
+		t.Errorf("want nil, got %v", err)
+	}
+
+	f := func(err error, want error) {
+		t.Helper()
+		if err != want {
+			t.Errorf("want %v, got %v", want, err)
+		}
+	}
+	sign := func(_ Signer, err error) error { return err }
+	verify := func(_ Verifier, err error) error { return err }
+	publicKeyErr := func(_ crypto.PublicKey, err error) error { return err }
+
+	f(sign(NewSignerForKey(MLDSA44, key)), ErrInvalidKey)
+	f(sign(NewSignerForKey(MLDSA65, rsaPrivateKey1)), ErrInvalidKey)
+	f(verify(NewVerifierForKey(MLDSA87, publicKey)), ErrInvalidKey)
+	f(sign(NewSignerMLDSA(ES256, key.Bytes())), ErrUnsupportedAlg)
+	f(sign(NewSignerMLDSA(MLDSA65, key.Bytes()[1:])), ErrInvalidKey)
+	f(verify(NewVerifierMLDSA(MLDSA44, key.PublicKey().Bytes())), ErrInvalidKey)
+	f(publicKeyErr((&JWK{KeyType: KeyTypeAKP, Pub: jwk.Pub}).PublicKey()), ErrInvalidKey)
+	f(publicKeyErr((&JWK{KeyType: KeyTypeAKP, Algorithm: MLDSA44, Pub: jwk.Pub}).PublicKey()), ErrInvalidKey)
+
+	if !(&JWK{KeyType: KeyTypeAKP, Priv: "c2VlZA"}).IsPrivate() {
+		t.Error("want private AKP JWK")
+	}
+	if !strings.HasPrefix(token.String(), "eyJhbGciOiJNTC1EU0EtNjUi") {
+		t.Errorf("want ML-DSA-65 header, got %s", token.String()[:40])
+	}
+}
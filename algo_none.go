@@ -0,0 +1,32 @@
+package jwt
+
+// NewSignerUnsafeNone returns a signer for unsigned tokens ("none" algorithm).
+// Such tokens aren't protected at all, use them only for tests and local development.
+func NewSignerUnsafeNone() Signer {
+	return &unsafeNoneAlg{}
+}
+
+// NewVerifierUnsafeNone returns a verifier for unsigned tokens ("none" algorithm).
+// Its Verify method always fails with ErrUnsafeNone, unsigned tokens are accepted
+// only by ParseAndVerify with WithUnsafeNoneAlgorithm option.
+func NewVerifierUnsafeNone() Verifier {
+	return &unsafeNoneAlg{}
+}
+
+type unsafeNoneAlg struct{}
+
+func (unsafeNoneAlg) Algorithm() Algorithm {
+	return None
+}
+
+func (unsafeNoneAlg) SignSize() int {
+	return 0
+}
+
+func (unsafeNoneAlg) Sign(payload []byte) ([]byte, error) {
+	return []byte{}, nil
+}
+
+func (unsafeNoneAlg) Verify(payload, signature []byte) error {
+	return ErrUnsafeNone
+}
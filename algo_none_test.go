@@ -0,0 +1,61 @@
+package jwt
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestUnsafeNone(t *testing.T) {
+	f := func(err error, want error) {
+		t.Helper()
+		if err != want {
+			t.Errorf("want %v, got %v", want, err)
+		}
+	}
+	parse := func(_ *Token, err error) error { return err }
+
+	signer := NewSignerUnsafeNone()
+	verifier := NewVerifierUnsafeNone()
+
+	token, err := NewBuilder(signer).Build(&StandardClaims{ID: "just an id"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw := token.String()
+
+	const want = "eyJhbGciOiJub25lIiwidHlwIjoiSldUIn0.eyJqdGkiOiJqdXN0IGFuIGlkIn0."
+	if raw != want {
+		t.Errorf("want %s, got %s", want, raw)
+	}
+	if alg := token.Header().Algorithm; alg != None {
+		t.Errorf("want %s, got %s", None, alg)
+	}
+
+	// rejected by default
+	f(parse(ParseAndVerifyString(raw, verifier)), ErrUnsafeNone)
+	f(parse(ParseAndVerifyString(raw, verifier, WithDenyList(nil))), ErrUnsafeNone)
+	f(verifier.Verify(token.Payload(), token.Signature()), ErrUnsafeNone)
+	f(parse(ParseAndVerifyString(raw, mustVerifier(NewCachedVerifier(verifier, 1)))), ErrUnsafeNone)
+	f(VerifyBatch([][]byte{token.Raw()}, func(Header) (Verifier, error) { return verifier, nil }, 1)[0].Err, ErrUnsafeNone)
+
+	// allowed only with the option
+	f(parse(ParseAndVerifyString(raw, verifier, WithUnsafeNoneAlgorithm())), nil)
+	f(parse(ParseAndVerifyString(raw+"c2lnbmF0dXJl", verifier, WithUnsafeNoneAlgorithm())), ErrInvalidSignature)
+
+	// other verifiers always reject it
+	hmacKey := []byte("key-0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWX")
+	hs := mustVerifier(NewVerifierHS(HS256, hmacKey))
+	f(parse(ParseAndVerifyString(raw, hs, WithUnsafeNoneAlgorithm())), ErrAlgorithmMismatch)
+	f(hs.Verify(token.Payload(), token.Signature()), ErrInvalidSignature)
+
+	hsToken, err := NewBuilder(mustSigner(NewSignerHS(HS256, hmacKey))).Build(&StandardClaims{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	stripped := hsToken.String()[:strings.LastIndexByte(hsToken.String(), '.')+1]
+	f(parse(ParseAndVerifyString(stripped, verifier, WithUnsafeNoneAlgorithm())), ErrAlgorithmMismatch)
+	f(parse(ParseAndVerifyString(stripped, hs)), ErrInvalidSignature)
+
+	_, err = NewVerifierForKey(None, rsaPublicKey1)
+	f(err, ErrUnsupportedAlg)
+}
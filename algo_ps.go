@@ -2,41 +2,61 @@ package jwt
 
 import (
 	"crypto"
-	"crypto/rand"
 	"crypto/rsa"
+	"io"
 )
 
 // NewSignerPS returns a new RSA-PSS-based signer.
-func NewSignerPS(alg Algorithm, key *rsa.PrivateKey) (Signer, error) {
+func NewSignerPS(alg Algorithm, key *rsa.PrivateKey, opts ...SignerOption) (Signer, error) {
 	if key == nil {
 		return nil, ErrInvalidKey
 	}
-	hash, opts, ok := getParamsPS(alg)
+	hash, pssOpts, ok := getParamsPS(alg)
 	if !ok {
 		return nil, ErrUnsupportedAlg
 	}
-	return &psAlg{
+	o := newSignerOptions(opts)
+	if err := o.Policy.checkRSA(&key.PublicKey); err != nil {
+		return nil, err
+	}
+	kid, err := o.getKeyID(&key.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	if o.PSSSaltLength != pssOpts.SaltLength {
+		pssOpts = &rsa.PSSOptions{SaltLength: o.PSSSaltLength, Hash: hash}
+	}
+	return &psSigner{
 		alg:        alg,
 		hash:       hash,
 		privateKey: key,
-		opts:       opts,
+		opts:       pssOpts,
+		kid:        kid,
+		random:     o.Random,
 	}, nil
 }
 
 // NewVerifierPS returns a new RSA-PSS-based signer.
-func NewVerifierPS(alg Algorithm, key *rsa.PublicKey) (Verifier, error) {
+func NewVerifierPS(alg Algorithm, key *rsa.PublicKey, opts ...VerifierOption) (Verifier, error) {
 	if key == nil {
 		return nil, ErrInvalidKey
 	}
-	hash, opts, ok := getParamsPS(alg)
+	hash, pssOpts, ok := getParamsPS(alg)
 	if !ok {
 		return nil, ErrUnsupportedAlg
 	}
-	return &psAlg{
+	o := newVerifierOptions(opts)
+	if err := o.Policy.checkRSA(key); err != nil {
+		return nil, err
+	}
+	if o.PSSSaltLength != pssOpts.SaltLength {
+		pssOpts = &rsa.PSSOptions{SaltLength: o.PSSSaltLength, Hash: hash}
+	}
+	return &psVerifier{
 		alg:       alg,
 		hash:      hash,
 		publicKey: key,
-		opts:      opts,
+		opts:      pssOpts,
 	}, nil
 }
 
@@ -53,58 +73,98 @@ func getParamsPS(alg Algorithm) (crypto.Hash, *rsa.PSSOptions, bool) {
 	}
 }
 
+// Salt length is equal to the hash size as required by RFC 7518.
+// See: https://tools.ietf.org/html/rfc7518#section-3.5
 var (
 	optsPS256 = &rsa.PSSOptions{
-		SaltLength: rsa.PSSSaltLengthAuto,
+		SaltLength: rsa.PSSSaltLengthEqualsHash,
 		Hash:       crypto.SHA256,
 	}
 
 	optsPS384 = &rsa.PSSOptions{
-		SaltLength: rsa.PSSSaltLengthAuto,
+		SaltLength: rsa.PSSSaltLengthEqualsHash,
 		Hash:       crypto.SHA384,
 	}
 
 	optsPS512 = &rsa.PSSOptions{
-		SaltLength: rsa.PSSSaltLengthAuto,
+		SaltLength: rsa.PSSSaltLengthEqualsHash,
 		Hash:       crypto.SHA512,
 	}
 )
 
-type psAlg struct {
+type psSigner struct {
 	alg        Algorithm
 	hash       crypto.Hash
-	publicKey  *rsa.PublicKey
 	privateKey *rsa.PrivateKey
 	opts       *rsa.PSSOptions
+	kid        string
+	random     io.Reader
 }
 
-func (ps psAlg) SignSize() int {
-	return ps.privateKey.Size()
+func (ps psSigner) Algorithm() Algorithm {
+	return ps.alg
 }
 
-func (ps psAlg) Algorithm() Algorithm {
-	return ps.alg
+func (ps psSigner) KeyID() string {
+	return ps.kid
 }
 
-func (ps psAlg) Sign(payload []byte) ([]byte, error) {
+func (ps psSigner) SignSize() int {
+	return ps.privateKey.PublicKey.Size()
+}
+
+func (ps psSigner) Sign(payload []byte) ([]byte, error) {
 	digest, err := hashPayload(ps.hash, payload)
 	if err != nil {
 		return nil, err
 	}
+	return ps.signDigest(digest)
+}
 
-	signature, errSign := rsa.SignPSS(rand.Reader, ps.privateKey, ps.hash, digest, ps.opts)
+func (ps psSigner) hashFunc() crypto.Hash {
+	return ps.hash
+}
+
+func (ps psSigner) signDigest(digest []byte) ([]byte, error) {
+	signature, errSign := rsa.SignPSS(ps.random, ps.privateKey, ps.hash, digest, ps.opts)
 	if errSign != nil {
 		return nil, errSign
 	}
 	return signature, nil
 }
 
-func (ps psAlg) Verify(payload, signature []byte) error {
+type psVerifier struct {
+	alg       Algorithm
+	hash      crypto.Hash
+	publicKey *rsa.PublicKey
+	opts      *rsa.PSSOptions
+}
+
+func (ps psVerifier) Algorithm() Algorithm {
+	return ps.alg
+}
+
+// SignSize returns size of the signatures accepted by the verifier.
+func (ps psVerifier) SignSize() int {
+	return ps.publicKey.Size()
+}
+
+func (ps psVerifier) Verify(payload, signature []byte) error {
 	digest, err := hashPayload(ps.hash, payload)
 	if err != nil {
 		return err
 	}
+	return ps.verifyDigest(digest, signature)
+}
 
+func (ps psVerifier) hashFunc() crypto.Hash {
+	return ps.hash
+}
+
+func (ps psVerifier) verifyDigest(digest, signature []byte) error {
+	if len(signature) != ps.SignSize() {
+		return ErrInvalidSignature
+	}
 	errVerify := rsa.VerifyPSS(ps.publicKey, ps.hash, digest, signature, ps.opts)
 	if errVerify != nil {
 		return ErrInvalidSignature
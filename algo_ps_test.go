@@ -1,6 +1,7 @@
 package jwt
 
 import (
+	"crypto/rsa"
 	"testing"
 )
 
@@ -106,3 +107,90 @@ func TestPS384_WithInvalidSignature(t *testing.T) {
 		},
 	)
 }
+
+func TestPSSaltLength(t *testing.T) {
+	payload := []byte("eyJhbGciOiJQUzI1NiIsInR5cCI6IkpXVCJ9.eyJzdWIiOiJzYWx0In0")
+
+	// signatures are produced by OpenSSL with rsa_pss_saltlen set to digest, max and 0
+	f := func(alg Algorithm, salt, sig string) {
+		t.Helper()
+
+		signature, err := b64Decode(sig)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var want error
+		if salt != "hash" {
+			want = ErrInvalidSignature
+		}
+		strict := mustVerifier(NewVerifierPS(alg, rsaPublicKey1))
+		if err := strict.Verify(payload, signature); err != want {
+			t.Errorf("%s with %s salt: want %v, got %v", alg, salt, want, err)
+		}
+
+		lenient := mustVerifier(NewVerifierPS(alg, rsaPublicKey1, WithVerifierPSSSaltLength(rsa.PSSSaltLengthAuto)))
+		if err := lenient.Verify(payload, signature); err != nil {
+			t.Errorf("%s with %s salt: want nil for lenient verifier, got %v", alg, salt, err)
+		}
+	}
+
+	f(PS256, "hash",
+		"WV4KrDjY2a2ePAWtm-190EoBwcVLIPZZsErmghl7zQlM8x5xKJZQ8EbsfCKy6jkQbVmpi32YmLAsjI28hoARp_FNvgV5MCLkdkOns2_aaeJWdQ_5Aa4zqPgYZIcn9Hw0N64de_5dqyFOMt8ujNFjLkyGnLx7v8cM7IBUTHXrvpn6iBetGRslAYwepMsQPcdICNEp9uW5BGgqYs-MAcEiXhEZnXJDnk2vNHlWT7JaUenQnwxbFTcEBz_8ti1_pRyh-p2iS0kXAQgti-tvC6ybB-tfTWUVEiUUUf6WbPXWL7tTxcvN1qbz08DZxxRX6YOHGcpVq1Ihg1ugAnOKICgKAw",
+	)
+	f(PS256, "max",
+		"oS4HNla33WSbaqm020z3-WTtiCTpjbqZj_s0ARUdD6vS6HhSb-uZFyFX9fKsgXTbj-BBKPsbHQ-Qewuc649DKLJ_RDE5KLxTGh0lRRgAI7qCYxjQnvY1MKDxSSgXRCphDbTmKTGFiccRF0NWuYzVk-PJ9ND2uj6hodfKr68afHboPS3fzstF0APIItLsFklKjEKB7mFwTCin6Xzf7cfVyqHz4WEr1nKU8ViGF6yCedbeTYKGQOWzWay2hm_EzOYXSaGqGC6Ewbbn-AostFeFxgE9IY6Xo1HlZQsiYOJ7F4cBJNNHt7cK0eE-4H90zdvKhnzjAv8grVN45EQBHmBq5w",
+	)
+	f(PS256, "zero",
+		"lC8BYO3mYts3Q1fv7kCBktw_3YENxie3_j5urELo9gKPx1yvoGsRo3CRFuGE0LsdqioitKFmJwi0sa30hx0naq47aLRPaj_ri9_ZliE_sHqa_vjHGwGyE0VBKQEI7Zn_E5hv0wLfi7Nhi0oP_e52fLuRsfRyXASFg_S-tc8oY1R1V8uNNVWuFNCem3l0vE8GhCdrLMUVpoFjvZSQKGty16F4NfJXiMsnw4-JWu6kA5dwZ511RS05pJ1GhF3WQEdhcDqNRymqCDV4_BwX6DMoyfJ6SzibMZnJ1lfu3LSKX6nPLipi0AXg7Xy8yTS_zPRPx-rzrE9PkowNhdOH1m5PLQ",
+	)
+	f(PS384, "hash",
+		"RS4bSfQBYR6o0GJfc7JHLmSXYto4v1Ddt7j-SOLVkFhZT04odKR9gxqqOix--J-ziNPe7uN5UGALWRvWXSXDywM6QFu57vo0ZfDOYF-56-YUSTr6NIjUrqGFDywQF6B6kvjHaO-l_LP40eaWAt0vRsNL2a0wOdsQtLbDkc3GZQsp87oWeZKG-O_08u-Yz878vlv9R2WqbE6ogKL7m3k3YhOuS8qb2yEJxMXLs1qHoMyXG7qxj9NJ-VPaLIkOFOM9geL5SD-P5JWIJjS3ndOCs2X44XMXVB5Ixw0ffuamnpmKDFwSkq6xB1dWIg1B_yyPLxJaDMmEnX_8JSS_uOYGfw",
+	)
+	f(PS384, "max",
+		"HFdiDp6982AtxMerq3Xl9zrv8HGDvnc53BboYER2Oe0JDsDEHXi6NpAnCK2m6LJQM9bJ13K9n11w2OmsAqtFrxm1YH3viF26atYLYge6AY5QweafSstxkWNo6lf4ClHzkRmbozi1UabV3Mmw7C3WQi4AAF39GTLeoObcBD8xw-lpnXTyAAjLDc4DFcAQBrE3yAO6gnsIws3S3Uy7tyus1v1_PlHUFrjB6AV9x2Yf7KCVhuhck0UrvLor-Gb3rnoFNBqQ2ijjhaXZQFwb7xirmiUYkZ3ChlnRTTZ-mQxq5fAkQYpPlGwcaBbNkTzk3tJZHrxycRgM6lQI8I8yFVN6SQ",
+	)
+	f(PS384, "zero",
+		"EneydZ8BO5MlHGZKatlOSJNfnzrFohEL4E_MMbTxyVCVj_rwtIfMVTaKQcZTBa53PWlLaF0QsuZ5AwMgNhLA6Yv49LFQ-4wV54y1gfjSkXs5JUQl0zAnkebk853AS8gd4HtJ7vfwyZbPTqsNkpVGU4RK3bu_Eb4F83bY6FfVIK0TaZNbI6277SKdHA3XwnxFybpB3Ny2Fq3cbSeCzgcFcxXRXsB6ZMPN6H7HkbqqstBxUT58vlKZFuxmB3A-RRfxS8CqVCtBgQQuPgRQI-BJx9Nm99drp4bX1PdCN6oo_juX9hZ3dJzueOyl6SSS3HV8DTURFvJGfZQbYrcRZYVdeQ",
+	)
+	f(PS512, "hash",
+		"F2S2a2w001qcCdC5rnwVHUx-4_-pVwWKjMpHnuUq4aP4UV6o-muvobc3KkRSwqJVbyifGRUxtrurlp3j3UhpMqwrbGP0cADcscf1RbnSysoyG2WNa3mtDvsg60-AC2VYkiUOvMV1wWriAsz7-6m_12-1iPA8yiMPFVy5__4t-rHZ9pbStqS7qugMxA-wpjdfudXM7R_1mKyDbLbtqhhgbvzwW136x3qgoRp1M5o7_jWuYCZa3dktmEo_EcHzYFEe44-KK6Ix_sv5d-n7VYZhPZbzRI1qqRgbPrIN-H3ktV5gUdR-Q6x8ee9A423rCwWZD2557NkIZIcJUSSEm1-kww",
+	)
+	f(PS512, "max",
+		"ikuNpe05bVjleS-2HfITtuNzfImSas122lfCmdnvi8ZOfRWiZA7iu-KjRgu8mnxKC6TRN2V5kCD1TcjPI_Z6WUYCfiBqMlkK4LcERLAmzFIxGF8L5vWhV0ky1V9qMDStfCqJgOYyabL33CFSjpkBjcQD_nEbbHTKZHdT4INkONFhTzo5uY6ekU5kXTsgIU_fpn3qYV6s0NLcrGLNTlM38AZIKFijWKHtwjD9MyTJyNKgqZlK8rX6WaMz5Y82Ur96RzLDK1T7sB7YJiZqmlJUziY3497TBqY3-SsurHLiwYWEPDj_PgGHQ6_YBCzemFm-4OhQa5IxDmY4gv4jEoBLOA",
+	)
+	f(PS512, "zero",
+		"hW79hpZFwXsZa1F8ojnH2LUe94Q7Ebk_LI5NjIgZ0U7TLSFkBwu-VwZItwvbKiHaFqtglRFFy8kl2bfncIfku3DnMPMiMVtPm67d-8dN9j0uwhthMYD-pnz6aZjSrENpd-ctyxFZfTztAcGZB1iETNlj3plOQypb_a8lmRWE6d4G0TeeV0NmlR9hb50I7NR5USGWo7vYAG0PwCL1PL1mo_r82QzY4QurVkf7I8qUFTqc-MbSNDY1EKAj9No3TQs9roO3GHrd0lF0_RjkcnePBCCb6nlAU5iRfJLGkMWcYQGLUvOov2w8XcmeIm8wWsmCIEF64ecvYA8Rd_GgIkJIag",
+	)
+}
+
+func TestPSSignerSaltLength(t *testing.T) {
+	payload := []byte("header.payload")
+
+	for _, alg := range []Algorithm{PS256, PS384, PS512} {
+		hash, _, _ := getParamsPS(alg)
+		strictOpts := &rsa.PSSOptions{SaltLength: hash.Size(), Hash: hash}
+
+		signature, err := mustSigner(NewSignerPS(alg, rsaPrivateKey1)).Sign(payload)
+		if err != nil {
+			t.Fatal(err)
+		}
+		digest, _ := hashPayload(hash, payload)
+		if err := rsa.VerifyPSS(rsaPublicKey1, hash, digest, signature, strictOpts); err != nil {
+			t.Errorf("%s: want hash size salt, got %v", alg, err)
+		}
+
+		legacy := mustSigner(NewSignerPS(alg, rsaPrivateKey1, WithPSSSaltLength(rsa.PSSSaltLengthAuto)))
+		signature, err = legacy.Sign(payload)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := mustVerifier(NewVerifierPS(alg, rsaPublicKey1)).Verify(payload, signature); err != ErrInvalidSignature {
+			t.Errorf("%s: want %v, got %v", alg, ErrInvalidSignature, err)
+		}
+		lenient := mustVerifier(NewVerifierPS(alg, rsaPublicKey1, WithVerifierPSSSaltLength(rsa.PSSSaltLengthAuto)))
+		if err := lenient.Verify(payload, signature); err != nil {
+			t.Errorf("%s: want nil, got %v", alg, err)
+		}
+	}
+}
@@ -2,12 +2,12 @@ package jwt
 
 import (
 	"crypto"
-	"crypto/rand"
 	"crypto/rsa"
+	"io"
 )
 
 // NewSignerRS returns a new RSA-based signer.
-func NewSignerRS(alg Algorithm, key *rsa.PrivateKey) (Signer, error) {
+func NewSignerRS(alg Algorithm, key *rsa.PrivateKey, opts ...SignerOption) (Signer, error) {
 	if key == nil {
 		return nil, ErrInvalidKey
 	}
@@ -15,15 +15,25 @@ func NewSignerRS(alg Algorithm, key *rsa.PrivateKey) (Signer, error) {
 	if !ok {
 		return nil, ErrUnsupportedAlg
 	}
-	return &rsAlg{
+	o := newSignerOptions(opts)
+	if err := o.Policy.checkRSA(&key.PublicKey); err != nil {
+		return nil, err
+	}
+	kid, err := o.getKeyID(&key.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	return &rsSigner{
 		alg:        alg,
 		hash:       hash,
 		privateKey: key,
+		kid:        kid,
+		random:     o.Random,
 	}, nil
 }
 
 // NewVerifierRS returns a new RSA-based verifier.
-func NewVerifierRS(alg Algorithm, key *rsa.PublicKey) (Verifier, error) {
+func NewVerifierRS(alg Algorithm, key *rsa.PublicKey, opts ...VerifierOption) (Verifier, error) {
 	if key == nil {
 		return nil, ErrInvalidKey
 	}
@@ -31,10 +41,13 @@ func NewVerifierRS(alg Algorithm, key *rsa.PublicKey) (Verifier, error) {
 	if !ok {
 		return nil, ErrUnsupportedAlg
 	}
-	return &rsAlg{
+	if err := newVerifierOptions(opts).Policy.checkRSA(key); err != nil {
+		return nil, err
+	}
+	return &rsVerifier{
 		alg:       alg,
 		hash:      hash,
-		publickey: key,
+		publicKey: key,
 	}, nil
 }
 
@@ -51,41 +64,78 @@ func getHashRSA(alg Algorithm) (crypto.Hash, bool) {
 	}
 }
 
-type rsAlg struct {
+type rsSigner struct {
 	alg        Algorithm
 	hash       crypto.Hash
-	publickey  *rsa.PublicKey
 	privateKey *rsa.PrivateKey
+	kid        string
+	random     io.Reader
 }
 
-func (rs rsAlg) Algorithm() Algorithm {
+func (rs rsSigner) Algorithm() Algorithm {
 	return rs.alg
 }
 
-func (rs rsAlg) SignSize() int {
-	return rs.privateKey.Size()
+func (rs rsSigner) KeyID() string {
+	return rs.kid
+}
+
+func (rs rsSigner) SignSize() int {
+	return rs.privateKey.PublicKey.Size()
 }
 
-func (rs rsAlg) Sign(payload []byte) ([]byte, error) {
+func (rs rsSigner) Sign(payload []byte) ([]byte, error) {
 	digest, err := hashPayload(rs.hash, payload)
 	if err != nil {
 		return nil, err
 	}
+	return rs.signDigest(digest)
+}
+
+func (rs rsSigner) hashFunc() crypto.Hash {
+	return rs.hash
+}
 
-	signature, errSign := rsa.SignPKCS1v15(rand.Reader, rs.privateKey, rs.hash, digest)
+func (rs rsSigner) signDigest(digest []byte) ([]byte, error) {
+	signature, errSign := rsa.SignPKCS1v15(rs.random, rs.privateKey, rs.hash, digest)
 	if errSign != nil {
 		return nil, errSign
 	}
 	return signature, nil
 }
 
-func (rs rsAlg) Verify(payload, signature []byte) error {
+type rsVerifier struct {
+	alg       Algorithm
+	hash      crypto.Hash
+	publicKey *rsa.PublicKey
+}
+
+func (rs rsVerifier) Algorithm() Algorithm {
+	return rs.alg
+}
+
+// SignSize returns size of the signatures accepted by the verifier.
+func (rs rsVerifier) SignSize() int {
+	return rs.publicKey.Size()
+}
+
+func (rs rsVerifier) Verify(payload, signature []byte) error {
 	digest, err := hashPayload(rs.hash, payload)
 	if err != nil {
 		return err
 	}
+	return rs.verifyDigest(digest, signature)
+}
+
+func (rs rsVerifier) hashFunc() crypto.Hash {
+	return rs.hash
+}
 
-	errVerify := rsa.VerifyPKCS1v15(rs.publickey, rs.hash, digest, signature)
+func (rs rsVerifier) verifyDigest(digest, signature []byte) error {
+	if len(signature) != rs.SignSize() {
+		return ErrInvalidSignature
+	}
+	errVerify := rsa.VerifyPKCS1v15(rs.publicKey, rs.hash, digest, signature)
 	if errVerify != nil {
 		return ErrInvalidSignature
 	}
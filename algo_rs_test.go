@@ -18,28 +18,28 @@ func init() {
 	}
 
 	rsaPublicKey1 = &rsa.PublicKey{
-		N: fromBase10("14314132931241006650998084889274020608918049032671858325988396851334124245188214251956198731333464217832226406088020736932173064754214329009979944037640912127943488972644697423190955557435910767690712778463524983667852819010259499695177313115447116110358524558307947613422897787329221478860907963827160223559690523660574329011927531289655711860504630573766609239332569210831325633840174683944553667352219670930408593321661375473885147973879086994006440025257225431977751512374815915392249179976902953721486040787792801849818254465486633791826766873076617116727073077821584676715609985777563958286637185868165868520557"),
-		E: 3,
+		N: fromBase10("23049636297528847898222268302443769995157935195764101587200909928206767412627368806844468994208742215867564083480111926243817474649609664286208854706353692567373215506479168439398663492689874303837294607786175081089667321896095634906793076671317046292936031676351556383746586643131451182158542415984215032949221112334253609778789541899027947647578407689509058444235047905293828483668162626453411498216791367592826270330454806718825328581522787500500330582159340180835769851028061449038993737864259436265305440061669307406984225076507888210514717491062750342417631502322502739102915796350206313991715937285666140442617"),
+		E: 65537,
 	}
 	rsaPrivateKey1 = &rsa.PrivateKey{
 		PublicKey: *rsaPublicKey1,
-		D:         fromBase10("9542755287494004433998723259516013739278699355114572217325597900889416163458809501304132487555642811888150937392013824621448709836142886006653296025093941418628992648429798282127303704957273845127141852309016655778568546006839666463451542076964744073572349705538631742281931858219480985907271975884773482372966847639853897890615456605598071088189838676728836833012254065983259638538107719766738032720239892094196108713378822882383694456030043492571063441943847195939549773271694647657549658603365629458610273821292232646334717612674519997533901052790334279661754176490593041941863932308687197618671528035670452762731"),
+		D:         fromBase10("1739704685074483820262698172910540737004268221704489502129931198557681538772926435495310525044685343711016765169818173599112610256104096989360698533728711114096031409780722442063040556732692421745444218951345271199628292533363398661312723404407735622442407810657689147385484395536105150869695440143215582960492774932443236045425593525349721671317315235084764378875425428457709918657425516324513618574400671370987416599155561939087503259687518285190906475172588827198481428454809688346985634779547255642213735916506178106515377412256947642457273678177307605127796912245966085945176676347738348222616176587288665098403"),
 		Primes: []*big.Int{
-			fromBase10("130903255182996722426771613606077755295583329135067340152947172868415809027537376306193179624298874215608270802054347609836776473930072411958753044562214537013874103802006369634761074377213995983876788718033850153719421695468704276694983032644416930879093914927146648402139231293035971427838068945045019075433"),
-			fromBase10("109348945610485453577574767652527472924289229538286649661240938988020367005475727988253438647560958573506159449538793540472829815903949343191091817779240101054552748665267574271163617694640513549693841337820602726596756351006149518830932261246698766355347898158548465400674856021497190430791824869615170301029"),
+			fromBase10("139618304759860536891628419362183865766731018624675484779833574977970128045215744918511772836660372015172491144053390905989817038171407841390017507038220838259881901447825166699750041080640518511053229426101005323463405937875041392295065109830485128575241491942485115044374285414078641286484427597518222773287"),
+			fromBase10("165090360731521260687537886651570074519068046250762601958245502895877573443285289831746178324536777516317011439740531793432357569806269812439649441564876753248711688255488977134270979398156774213615071090422159443937621025404588930072003529174000073387436763060927484649051197016845730429764742276617088890591"),
 		},
 	}
 
 	rsaPublicKey2 = &rsa.PublicKey{
-		N: fromBase10("887256638780856047914581579814082241228904817182093261763058947771445376334361454028526564964284108022499562499436107360797167855238681264022398768822093355881651855771536641100347179464316604983028325045398953751392004347747368839824636917782721516826693522992183854650791268736264416865889799352514216298521461994511678593557464913439951619056304159812403323561755303818800310137159146108075344562143531014989113550013919515074346824023514684979514198941230125010212380546452298362548591023662125669118549327451011201031961777374564883854203295916398755760815894642777422649649856180524299517641811337173222323171868"),
-		E: 3,
+		N: fromBase10("30741901440425127056342532364477058377301820421027973103134880659165714608682131850496197308595140367918670438238303301485167083491898098792733205893206359082869449982526024634934172992587016146484240939402803330954284838291598002322487077834666610508186598075032993933382224559283446710512138027326059446481491861650384430415088720956229753057084483034404926893205401708877267462326024103050433000562553579410881842395604654826892721390629315110486784540637490489482093758889576217824842070350428865065740720223030101889955713061712765542224487872923019481970783035068255319615238416364840268767165755518390090404873"),
+		E: 65537,
 	}
 	rsaPrivateKey2 = &rsa.PrivateKey{
 		PublicKey: *rsaPublicKey2,
-		D:         fromBase10("9542755287494004433998723259516013739278699355114572217325597900889416163458809501304132487555642811888150937392013824621448709836142886006653296025093941418628992648429798282127303704957273845127141852309016655778568546006839666463451542076964744073572349705538631742281931858219480985907271975884773482372966847639853897890615456605598071088189838676728836833012254065983259638538107719766738032720239892094196108713378822882383694456030043492571063441943847195939549773271694647657549658603365629458610273821292232646334717612674519997533901052790334279661754176490593041941863932308687197618671528035670452762731"),
+		D:         fromBase10("6332774469329682894001134598572142860105943613441081387290728235028559898599464745832490223304220402781421338349881955562514277594067019893362446345747172601710163869098258931350669125630117513551283928199452925363509505460361609935681169328881648155481226899491629683959087578964648554484781412605329288145912180036403373715210884299297651359011767347281989801224975296174704243657254370156877788947876789097056894999624934662324307963749834082267678848019956474262097893832605912212137530026200592121214317159490315355524302886662549845875219734661731721845270649154465504687288801506345052023408156242172752075515"),
 		Primes: []*big.Int{
-			fromBase10("118824431543227186571027026092363352199324615266444280871852738808378452065820764461459770308550270192458287541328152308596233985238045601785765785685887955407926672604456991769558426224026688264520894241180697554536183714315783324715006626168049073199347594517685254572389805736483316580732446129950642427691"),
-			fromBase10("167151560084187340876600839609071108780428930679164316900248316388276108158369650170260479635956969047912292329416883197284109866041024250716279228267907058587184812901048552331276732325276799660817363854674774994782375599727993270112962045907225952829559312944081207881075710161619024002861307509427534745757"),
+			fromBase10("176736441834811760831630421736796166607458920052162026701959406237458804388820826584790041339994138196922003963349138138179975511592504103715723753735423798595937113491874357690964696068598732053100702572812554097433556324243178769866268838284416075167755886636764776842235086921586852973140341982555107845487"),
+			fromBase10("173942063794394538574284392270803850548562454501665603299767921290851803260303927776979601993049339584107779057386486433185308301149112310457822857737673708869556544060159275144665322481740639597727777090125936559234779360098116882419011171927895517867543089793943357062041055133314104327183419858321138422279"),
 		},
 	}
 }
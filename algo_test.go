@@ -1,7 +1,12 @@
 package jwt
 
 import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
 	"testing"
+
+	"github.com/cloudflare/circl/sign/ed448"
 )
 
 func TestSignerAlg(t *testing.T) {
@@ -12,7 +17,7 @@ func TestSignerAlg(t *testing.T) {
 		}
 	}
 
-	hmacKey := []byte("key")
+	hmacKey := []byte("key-0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWX")
 	f(mustSigner(NewSignerHS(HS256, hmacKey)), HS256)
 	f(mustSigner(NewSignerHS(HS384, hmacKey)), HS384)
 	f(mustSigner(NewSignerHS(HS512, hmacKey)), HS512)
@@ -26,10 +31,9 @@ func TestSignerAlg(t *testing.T) {
 	f(mustSigner(NewSignerPS(PS384, rsaPriv)), PS384)
 	f(mustSigner(NewSignerPS(PS512, rsaPriv)), PS512)
 
-	ecdsaPriv := ecdsaPrivateKey256
-	f(mustSigner(NewSignerES(ES256, ecdsaPriv)), ES256)
-	f(mustSigner(NewSignerES(ES384, ecdsaPriv)), ES384)
-	f(mustSigner(NewSignerES(ES512, ecdsaPriv)), ES512)
+	f(mustSigner(NewSignerES(ES256, ecdsaPrivateKey256)), ES256)
+	f(mustSigner(NewSignerES(ES384, ecdsaPrivateKey384)), ES384)
+	f(mustSigner(NewSignerES(ES512, ecdsaPrivateKey521)), ES512)
 }
 
 func TestVerifierAlg(t *testing.T) {
@@ -40,7 +44,7 @@ func TestVerifierAlg(t *testing.T) {
 		}
 	}
 
-	hmacKey := []byte("key")
+	hmacKey := []byte("key-0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWX")
 	f(mustVerifier(NewVerifierHS(HS256, hmacKey)), HS256)
 	f(mustVerifier(NewVerifierHS(HS384, hmacKey)), HS384)
 	f(mustVerifier(NewVerifierHS(HS512, hmacKey)), HS512)
@@ -54,10 +58,9 @@ func TestVerifierAlg(t *testing.T) {
 	f(mustVerifier(NewVerifierPS(PS384, rsaPub)), PS384)
 	f(mustVerifier(NewVerifierPS(PS512, rsaPub)), PS512)
 
-	ecdsaPub := ecdsaPublicKey256
-	f(mustVerifier(NewVerifierES(ES256, ecdsaPub)), ES256)
-	f(mustVerifier(NewVerifierES(ES384, ecdsaPub)), ES384)
-	f(mustVerifier(NewVerifierES(ES512, ecdsaPub)), ES512)
+	f(mustVerifier(NewVerifierES(ES256, ecdsaPublicKey256)), ES256)
+	f(mustVerifier(NewVerifierES(ES384, ecdsaPublicKey384)), ES384)
+	f(mustVerifier(NewVerifierES(ES512, ecdsaPublicKey521)), ES512)
 }
 
 func TestSignerBadParams(t *testing.T) {
@@ -107,3 +110,152 @@ func TestVerifierBadParams(t *testing.T) {
 	f(NewVerifierPS("xxx", rsaPublicKey1))
 	f(NewVerifierES("xxx", ecdsaPublicKey256))
 }
+
+func TestKeyPolicy(t *testing.T) {
+	f := func(err error, want error) {
+		t.Helper()
+		if err != want {
+			t.Errorf("want %#v, got %#v", want, err)
+		}
+	}
+
+	key32 := []byte("0123456789abcdef0123456789abcdef")
+	key64 := append(append([]byte{}, key32...), key32...)
+	rsaKey1024, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sign := func(_ Signer, err error) error { return err }
+	verify := func(_ Verifier, err error) error { return err }
+
+	// HMAC key must be at least hash size
+	f(sign(NewSignerHS(HS256, key32)), nil)
+	f(sign(NewSignerHS(HS256, key32[:31])), ErrWeakKey)
+	f(sign(NewSignerHS(HS384, key32)), ErrWeakKey)
+	f(sign(NewSignerHS(HS512, key64)), nil)
+	f(sign(NewSignerHS(HS512, key64[:63])), ErrWeakKey)
+	f(verify(NewVerifierHS(HS256, key32[:1])), ErrWeakKey)
+	f(verify(NewVerifierHS(HS384, key64[:48])), nil)
+
+	// RSA key must be at least 2048 bits
+	f(sign(NewSignerRS(RS256, rsaKey1024)), ErrWeakKey)
+	f(sign(NewSignerPS(PS256, rsaKey1024)), ErrWeakKey)
+	f(verify(NewVerifierRS(RS256, &rsaKey1024.PublicKey)), ErrWeakKey)
+	f(verify(NewVerifierPS(PS256, &rsaKey1024.PublicKey)), ErrWeakKey)
+	f(verify(NewVerifierForKey(RS256, &rsaKey1024.PublicKey)), ErrWeakKey)
+
+	// ECDSA curve must match the algorithm
+	f(sign(NewSignerES(ES384, ecdsaPrivateKey256)), ErrInvalidCurve)
+	f(verify(NewVerifierES(ES256, ecdsaPublicKey521)), ErrInvalidCurve)
+
+	// custom policies
+	legacy := KeyPolicy{MinHMACKeySize: 16, MinRSAKeySize: 1024}
+	f(sign(NewSignerHS(HS512, key32[:16], WithKeyPolicy(legacy))), nil)
+	f(sign(NewSignerHS(HS256, key32[:15], WithKeyPolicy(legacy))), ErrWeakKey)
+	f(sign(NewSignerRS(RS256, rsaKey1024, WithKeyPolicy(legacy))), nil)
+	f(verify(NewVerifierPS(PS256, &rsaKey1024.PublicKey, WithVerifierKeyPolicy(legacy))), nil)
+	f(verify(NewVerifierES(ES256, ecdsaPublicKey521, WithVerifierKeyPolicy(legacy))), ErrInvalidCurve)
+	f(sign(NewSignerRS(RS256, rsaPrivateKey1, WithKeyPolicy(KeyPolicy{MinRSAKeySize: 4096}))), ErrWeakKey)
+
+	f(sign(NewSignerHS(HS256, []byte("k"), WithKeyPolicy(InsecureKeyPolicy))), nil)
+	f(verify(NewVerifierHS(HS512, []byte("k"), WithVerifierKeyPolicy(InsecureKeyPolicy))), nil)
+	f(verify(NewVerifierRS(RS256, &rsaKey1024.PublicKey, WithVerifierKeyPolicy(InsecureKeyPolicy))), nil)
+	f(sign(NewSignerES(ES512, ecdsaPrivateKey256, WithKeyPolicy(InsecureKeyPolicy))), nil)
+
+	// changed default policy
+	defer func(p KeyPolicy) { DefaultKeyPolicy = p }(DefaultKeyPolicy)
+	DefaultKeyPolicy = legacy
+	f(sign(NewSignerRS(RS256, rsaKey1024)), nil)
+	f(verify(NewVerifierRS(RS256, &rsaKey1024.PublicKey)), nil)
+	f(sign(NewSignerHS(HS256, key32[:15])), ErrWeakKey)
+	f(sign(NewSignerRS(RS256, rsaKey1024, WithKeyPolicy(KeyPolicy{}))), ErrWeakKey)
+}
+
+func TestAlgNoPanic(t *testing.T) {
+	_, edKey, _ := ed25519.GenerateKey(rand.Reader)
+	hmacKey := []byte("key-0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWX")
+
+	var algs []interface{}
+	signers := map[interface{}]bool{}
+	addSigner := func(s Signer, err error) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		signers[s] = true
+		algs = append(algs, s)
+	}
+	addVerifier := func(v Verifier, err error) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		algs = append(algs, v)
+	}
+
+	for _, alg := range []Algorithm{HS256, HS384, HS512} {
+		addSigner(NewSignerHS(alg, hmacKey))
+		addVerifier(NewVerifierHS(alg, hmacKey))
+	}
+	for _, alg := range []Algorithm{RS256, RS384, RS512} {
+		addSigner(NewSignerRS(alg, rsaPrivateKey1))
+		addVerifier(NewVerifierRS(alg, rsaPublicKey1))
+	}
+	for _, alg := range []Algorithm{PS256, PS384, PS512} {
+		addSigner(NewSignerPS(alg, rsaPrivateKey1))
+		addVerifier(NewVerifierPS(alg, rsaPublicKey1))
+	}
+	addSigner(NewSignerES(ES256, ecdsaPrivateKey256))
+	addVerifier(NewVerifierES(ES256, ecdsaPublicKey256))
+	addSigner(NewSignerES(ES384, ecdsaPrivateKey384))
+	addVerifier(NewVerifierES(ES384, ecdsaPublicKey384))
+	addSigner(NewSignerES(ES512, ecdsaPrivateKey521))
+	addVerifier(NewVerifierES(ES512, ecdsaPublicKey521))
+	addSigner(NewSignerEdDSA(edKey))
+	addVerifier(NewVerifierEdDSA(edKey.Public().(ed25519.PublicKey)))
+	ed448Pub, ed448Key, _ := ed448.GenerateKey(rand.Reader)
+	addSigner(NewSignerEdDSA(ed448Key))
+	addVerifier(NewVerifierEdDSA(ed448Pub))
+
+	payload := []byte("header.payload")
+	signatures := [][]byte{nil, {}, {1}, make([]byte, 64), make([]byte, 256), make([]byte, 1024)}
+
+	for _, alg := range algs {
+		alg.(interface{ Algorithm() Algorithm }).Algorithm()
+
+		size := -1
+		if s, ok := alg.(interface{ SignSize() int }); ok {
+			size = s.SignSize()
+			if size <= 0 {
+				t.Errorf("%T: want positive sign size, got %d", alg, size)
+			}
+		}
+
+		if s, ok := alg.(Signer); ok {
+			signature, err := s.Sign(payload)
+			switch {
+			case !signers[alg]:
+				// verifier without a private key can't sign
+				if err != nil && err != ErrInvalidKey {
+					t.Errorf("%T: want %v, got %v", alg, ErrInvalidKey, err)
+				}
+			case err != nil:
+				t.Errorf("%T: want nil, got %v", alg, err)
+			case len(signature) != size:
+				t.Errorf("%T: want signature of %d bytes, got %d", alg, size, len(signature))
+			}
+		}
+
+		if v, ok := alg.(Verifier); ok {
+			for _, signature := range signatures {
+				if err := v.Verify(payload, signature); err != ErrInvalidSignature {
+					t.Errorf("%T: want %v, got %v", alg, ErrInvalidSignature, err)
+				}
+			}
+			if size > 0 {
+				if err := v.Verify(payload, make([]byte, size)); err != ErrInvalidSignature {
+					t.Errorf("%T: want %v, got %v", alg, ErrInvalidSignature, err)
+				}
+			}
+		}
+	}
+}
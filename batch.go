@@ -0,0 +1,102 @@
+package jwt
+
+import (
+	"crypto"
+	"hash"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// KeyResolver returns a verifier for a token with the given header.
+type KeyResolver func(header Header) (Verifier, error)
+
+// BatchResult is a result of a token verification, see VerifyBatch.
+type BatchResult struct {
+	Token *Token
+	Err   error
+}
+
+// VerifyBatch decodes tokens and verifies their signatures in parallel.
+// At most workers goroutines are used, GOMAXPROCS if workers isn't positive.
+// Results are in the same order as tokens. Each worker reuses it's own hash state.
+//
+func VerifyBatch(tokens [][]byte, resolver KeyResolver, workers int) []BatchResult {
+	results := make([]BatchResult, len(tokens))
+	if len(tokens) == 0 {
+		return results
+	}
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > len(tokens) {
+		workers = len(tokens)
+	}
+
+	var next int64 = -1
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+
+			w := &batchWorker{hashes: make(map[crypto.Hash]hash.Hash)}
+			for {
+				idx := int(atomic.AddInt64(&next, 1))
+				if idx >= len(tokens) {
+					return
+				}
+				token, err := w.verify(tokens[idx], resolver)
+				results[idx] = BatchResult{Token: token, Err: err}
+			}
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+// batchWorker keeps hash state between verifications.
+type batchWorker struct {
+	hashes map[crypto.Hash]hash.Hash
+	digest []byte
+}
+
+func (w *batchWorker) verify(raw []byte, resolver KeyResolver) (*Token, error) {
+	token, err := Parse(raw)
+	if err != nil {
+		return nil, err
+	}
+	verifier, err := resolver(token.Header())
+	if err != nil {
+		return nil, err
+	}
+	if verifier == nil {
+		return nil, ErrInvalidKey
+	}
+	if token.Header().Algorithm != verifier.Algorithm() {
+		return nil, ErrAlgorithmMismatch
+	}
+
+	dv, ok := verifier.(digestVerifier)
+	if !ok {
+		err = verifyToken(verifier, token)
+	} else {
+		err = dv.verifyDigest(w.hash(dv.hashFunc(), token.Payload()), token.Signature())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+func (w *batchWorker) hash(hashFunc crypto.Hash, payload []byte) []byte {
+	hasher, ok := w.hashes[hashFunc]
+	if !ok {
+		hasher = hashFunc.New()
+		w.hashes[hashFunc] = hasher
+	}
+	hasher.Reset()
+	hasher.Write(payload)
+	w.digest = hasher.Sum(w.digest[:0])
+	return w.digest
+}
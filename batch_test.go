@@ -0,0 +1,88 @@
+package jwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"errors"
+	"testing"
+)
+
+func TestVerifyBatch(t *testing.T) {
+	_, edKey, _ := ed25519.GenerateKey(rand.Reader)
+	otherKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	hsKey := []byte("batch-test-key-with-enough-bytes-0123456789abcdefghijklmnopqrstu")
+
+	verifiers := map[string]Verifier{
+		"hs": mustVerifier(NewVerifierHS(HS256, hsKey)),
+		"rs": mustVerifier(NewVerifierRS(RS256, rsaPublicKey1)),
+		"ps": mustVerifier(NewVerifierPS(PS384, rsaPublicKey1)),
+		"es": mustVerifier(NewVerifierES(ES256, ecdsaPublicKey256)),
+		"ed": mustVerifier(NewVerifierEdDSA(edKey.Public().(ed25519.PublicKey))),
+	}
+	errUnknownKey := errors.New("unknown key")
+	resolver := func(header Header) (Verifier, error) {
+		v, ok := verifiers[header.KeyID]
+		if !ok {
+			return nil, errUnknownKey
+		}
+		return v, nil
+	}
+
+	build := func(signer Signer, sub string) []byte {
+		token, err := NewBuilder(signer).Build(&StandardClaims{Subject: sub})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return token.Raw()
+	}
+
+	tokens := [][]byte{
+		build(mustSigner(NewSignerHS(HS256, hsKey, WithKeyID("hs"))), "0"),
+		build(mustSigner(NewSignerRS(RS256, rsaPrivateKey1, WithKeyID("rs"))), "1"),
+		build(mustSigner(NewSignerPS(PS384, rsaPrivateKey1, WithKeyID("ps"))), "2"),
+		build(mustSigner(NewSignerES(ES256, ecdsaPrivateKey256, WithKeyID("es"))), "3"),
+		build(mustSigner(NewSignerEdDSA(edKey, WithKeyID("ed"))), "4"),
+		build(mustSigner(NewSignerES(ES256, otherKey, WithKeyID("es"))), "5"),
+		build(mustSigner(NewSignerES(ES256, ecdsaPrivateKey256, WithKeyID("rs"))), "6"),
+		build(mustSigner(NewSignerHS(HS256, hsKey, WithKeyID("other"))), "7"),
+		[]byte("xyz.xyz"),
+		build(mustSigner(NewSignerES(ES256, ecdsaPrivateKey256, WithKeyID("es"))), "9"),
+	}
+	wantErrs := []error{
+		nil, nil, nil, nil, nil,
+		ErrInvalidSignature,
+		ErrAlgorithmMismatch,
+		errUnknownKey,
+		ErrInvalidFormat,
+		nil,
+	}
+
+	for _, workers := range []int{0, 1, 3, 100} {
+		results := VerifyBatch(tokens, resolver, workers)
+		if len(results) != len(tokens) {
+			t.Fatalf("want %d results, got %d", len(tokens), len(results))
+		}
+
+		for i, res := range results {
+			if res.Err != wantErrs[i] {
+				t.Errorf("workers %d, token %d: want %v, got %v", workers, i, wantErrs[i], res.Err)
+				continue
+			}
+			if res.Err != nil {
+				if res.Token != nil {
+					t.Errorf("workers %d, token %d: want nil token", workers, i)
+				}
+				continue
+			}
+			if string(res.Token.Raw()) != string(tokens[i]) {
+				t.Errorf("workers %d, token %d: results are out of order", workers, i)
+			}
+		}
+	}
+
+	if results := VerifyBatch(nil, resolver, 4); len(results) != 0 {
+		t.Errorf("want no results, got %d", len(results))
+	}
+}
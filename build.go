@@ -3,6 +3,7 @@ package jwt
 import (
 	"encoding/base64"
 	"encoding/json"
+	"hash"
 )
 
 var (
@@ -27,8 +28,33 @@ func Build(signer Signer, claims interface{}) (*Token, error) {
 	return NewBuilder(signer).Build(claims)
 }
 
+// BuilderOption is used to configure a Builder.
+type BuilderOption func(*Builder)
+
+// WithType sets "typ" header of the tokens, "JWT" is used by default.
+func WithType(typ string) BuilderOption {
+	return func(b *Builder) {
+		b.header.Type = typ
+	}
+}
+
+// WithContentType sets "cty" header of the tokens.
+func WithContentType(cty string) BuilderOption {
+	return func(b *Builder) {
+		b.header.ContentType = cty
+	}
+}
+
+// WithJWK sets "jwk" header of the tokens.
+func WithJWK(jwk *JWK) BuilderOption {
+	return func(b *Builder) {
+		b.header.JWK = jwk
+	}
+}
+
 // NewBuilder returns new instance of Builder.
-func NewBuilder(signer Signer) *Builder {
+// If signer has a key ID (see WithKeyID) it's set as "kid" header.
+func NewBuilder(signer Signer, opts ...BuilderOption) *Builder {
 	b := &Builder{
 		signer: signer,
 		header: Header{
@@ -36,23 +62,89 @@ func NewBuilder(signer Signer) *Builder {
 			Type:      "JWT",
 		},
 	}
+	if s, ok := signer.(keyIDSigner); ok {
+		b.header.KeyID = s.KeyID()
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
 	b.headerRaw = encodeHeader(b.header)
 	return b
 }
 
 // BuildBytes used to create and encode JWT with a provided claims.
 func (b *Builder) BuildBytes(claims interface{}) ([]byte, error) {
-	token, err := b.Build(claims)
-	if err != nil {
-		return nil, err
+	return b.AppendBuild(nil, claims)
+}
+
+// EncodedClaims are already marshaled and base64url encoded claims.
+// Builder uses them as is.
+type EncodedClaims []byte
+
+// AppendBuild appends JWT with a provided claims to dst and returns the extended buffer.
+// Claims are handled as in Build, EncodedClaims are appended as is
+// after checking they're unpadded base64url, otherwise ErrInvalidFormat is returned.
+// Other claims are streamed from json.Encoder into a base64url encoder which appends to dst
+// and writes to the hash of RS, PS and ES signers. Only the pooled buffer of encoding/json
+// holds the marshaled JSON, it isn't copied.
+//
+// Spare capacity of dst is used as a signature buffer, so HS and EdDSA tokens
+// are built without allocations when dst is reused and claims are []byte or EncodedClaims.
+//
+func (b *Builder) AppendBuild(dst []byte, claims interface{}) ([]byte, error) {
+	start := len(dst)
+	dst = append(dst, b.headerRaw...)
+	dst = append(dst, '.')
+
+	// header and claims are hashed while they're encoded
+	var hasher hash.Hash
+	ds, isDigestSigner := b.signer.(digestSigner)
+	if isDigestSigner {
+		hasher = ds.hashFunc().New()
+		hasher.Write(dst[start:])
 	}
-	return token.Raw(), nil
+
+	dst, errClaims := appendClaims(dst, claims, hasher)
+	if errClaims != nil {
+		return dst[:start], errClaims
+	}
+	idx := len(dst)
+
+	signSize := b.signer.SignSize()
+	lenS := b64EncodedLen(signSize)
+	dst = grow(dst, 1+lenS+signSize)
+
+	// signature or digest is written after the space reserved for the encoded signature
+	scratch := dst[idx+1+lenS : idx+1+lenS]
+	var signature []byte
+	var errSign error
+	switch signer := b.signer.(type) {
+	case digestSigner:
+		signature, errSign = signer.signDigest(hasher.Sum(scratch))
+	case appendSigner:
+		signature, errSign = signer.appendSign(scratch, dst[start:idx])
+	default:
+		signature, errSign = b.signer.Sign(dst[start:idx])
+	}
+	if errSign != nil {
+		return dst[:start], errSign
+	}
+
+	// add '.' and append encoded signature
+	dst = dst[:idx+1+lenS]
+	dst[idx] = '.'
+	b64Encode(dst[idx+1:], signature)
+	return dst, nil
 }
 
 // Build used to create and encode JWT with a provided claims.
 // If claims param is of type []byte then it's treated as a marshaled JSON.
 // In other words you can pass already marshaled claims.
 //
+// Token keeps marshaled claims (see Token.RawClaims), so they're marshaled once
+// and encoded into a token of the exact size. Use BuildBytes or AppendBuild
+// to stream claims into the token without keeping marshaled JSON.
+//
 func (b *Builder) Build(claims interface{}) (*Token, error) {
 	rawClaims, errClaims := encodeClaims(claims)
 	if errClaims != nil {
@@ -99,16 +191,134 @@ func encodeClaims(claims interface{}) ([]byte, error) {
 	switch claims := claims.(type) {
 	case []byte:
 		return claims, nil
+	case EncodedClaims:
+		raw, err := base64.RawURLEncoding.DecodeString(string(claims))
+		if err != nil {
+			return nil, ErrInvalidFormat
+		}
+		return raw, nil
 	default:
 		return json.Marshal(claims)
 	}
 }
 
+// appendClaims appends encoded claims to dst and writes them to hasher if it's not nil.
+func appendClaims(dst []byte, claims interface{}, hasher hash.Hash) ([]byte, error) {
+	idx := len(dst)
+	switch claims := claims.(type) {
+	case EncodedClaims:
+		if !isBase64URL(claims) {
+			return dst, ErrInvalidFormat
+		}
+		dst = append(dst, claims...)
+	case []byte:
+		dst = appendEncoded(dst, claims)
+	default:
+		w := &claimsWriter{buf: dst, hash: hasher}
+		if err := json.NewEncoder(w).Encode(claims); err != nil {
+			return dst, err
+		}
+		w.flush()
+		return w.buf, nil
+	}
+
+	if hasher != nil {
+		hasher.Write(dst[idx:])
+	}
+	return dst, nil
+}
+
+// isBase64URL reports whether b is unpadded base64url encoded, as Build and Parse expect.
+func isBase64URL(b []byte) bool {
+	if len(b)%4 == 1 {
+		return false
+	}
+	for _, c := range b {
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9', c == '-', c == '_':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func appendEncoded(dst, src []byte) []byte {
+	n := b64EncodedLen(len(src))
+	dst = grow(dst, n)
+	b64Encode(dst[len(dst):len(dst)+n], src)
+	return dst[:len(dst)+n]
+}
+
+// claimsWriter is a base64url encoder for json.Encoder,
+// it appends encoded bytes to buf and writes them to hash if it's not nil.
+// Groups of 3 bytes are encoded as they're written, the rest is kept until the next write.
+type claimsWriter struct {
+	buf  []byte
+	hash hash.Hash
+	rem  [3]byte
+	nrem int
+}
+
+func (w *claimsWriter) Write(p []byte) (int, error) {
+	n := len(p)
+	// json.Encoder ends each value with a newline, compact JSON has no other newlines
+	if n > 0 && p[n-1] == '\n' {
+		p = p[:n-1]
+	}
+
+	// complete a group of 3 bytes left from the previous write
+	for w.nrem > 0 && w.nrem < 3 && len(p) > 0 {
+		w.rem[w.nrem] = p[0]
+		w.nrem++
+		p = p[1:]
+	}
+	if w.nrem == 3 {
+		w.append(w.rem[:])
+		w.nrem = 0
+	}
+
+	full := len(p) - len(p)%3
+	w.append(p[:full])
+	w.nrem += copy(w.rem[w.nrem:], p[full:])
+	return n, nil
+}
+
+// flush encodes the rest of the written bytes.
+func (w *claimsWriter) flush() {
+	w.append(w.rem[:w.nrem])
+	w.nrem = 0
+}
+
+func (w *claimsWriter) append(p []byte) {
+	if len(p) == 0 {
+		return
+	}
+	idx := len(w.buf)
+	w.buf = appendEncoded(w.buf, p)
+	if w.hash != nil {
+		w.hash.Write(w.buf[idx:])
+	}
+}
+
+// grow guarantees space for another n bytes in buf.
+func grow(buf []byte, n int) []byte {
+	if cap(buf)-len(buf) >= n {
+		return buf
+	}
+	newBuf := make([]byte, len(buf), 2*cap(buf)+n)
+	copy(newBuf, buf)
+	return newBuf
+}
+
 func encodeHeader(header Header) []byte {
-	if header.Type == "JWT" && header.ContentType == "" {
+	if header.Type == "JWT" && header.ContentType == "" && header.KeyID == "" && header.JWK == nil {
 		if h := getPredefinedHeader(header); h != "" {
 			return []byte(h)
 		}
+		if a, ok := lookupAlgorithm(header.Algorithm); ok {
+			return []byte(a.header)
+		}
 		// another algorithm? encode below
 	}
 	// returned err is always nil, see *Header.MarshalJSON
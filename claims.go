@@ -1,6 +1,7 @@
 package jwt
 
 import (
+	"crypto/rand"
 	"crypto/subtle"
 	"time"
 )
@@ -82,6 +83,25 @@ func (sc StandardClaims) IsValidAt(now time.Time) bool {
 	return sc.IsValidExpiresAt(now) && sc.IsValidNotBefore(now) && sc.IsValidIssuedAt(now)
 }
 
+func (sc StandardClaims) validateAt(now time.Time) error {
+	if !sc.IsValidExpiresAt(now) {
+		return ErrTokenExpired
+	}
+	if !sc.IsValidNotBefore(now) || !sc.IsValidIssuedAt(now) {
+		return ErrTokenNotYetValid
+	}
+	return nil
+}
+
 func constTimeEqual(a, b string) bool {
 	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
 }
+
+// newRandomID returns a random value for "jti" claim.
+func newRandomID() (string, error) {
+	var id [16]byte
+	if _, err := rand.Read(id[:]); err != nil {
+		return "", err
+	}
+	return b64EncodeToString(id[:]), nil
+}
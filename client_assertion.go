@@ -0,0 +1,155 @@
+package jwt
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// ClientAssertionType is a value of "client_assertion_type" parameter for JWT client assertions.
+// See: https://tools.ietf.org/html/rfc7523#section-2.2
+const ClientAssertionType = "urn:ietf:params:oauth:client-assertion-type:jwt-bearer"
+
+// ClientAssertionBuilder is used to create JWT client assertions (private_key_jwt).
+// See: https://tools.ietf.org/html/rfc7523#section-3
+//
+type ClientAssertionBuilder struct {
+	builder  *Builder
+	clientID string
+	audience string
+	lifetime time.Duration
+}
+
+// ClientAssertionOption is used to configure a ClientAssertionBuilder.
+type ClientAssertionOption func(*ClientAssertionBuilder)
+
+// WithClientAssertionLifetime sets lifetime of the assertions, default is 1 minute.
+func WithClientAssertionLifetime(lifetime time.Duration) ClientAssertionOption {
+	return func(b *ClientAssertionBuilder) {
+		b.lifetime = lifetime
+	}
+}
+
+// NewClientAssertionBuilder returns a new ClientAssertionBuilder for the given client.
+// Audience is the token endpoint URL or the issuer identifier of the authorization server.
+func NewClientAssertionBuilder(signer Signer, clientID, audience string, opts ...ClientAssertionOption) *ClientAssertionBuilder {
+	b := &ClientAssertionBuilder{
+		builder:  NewBuilder(signer),
+		clientID: clientID,
+		audience: audience,
+		lifetime: time.Minute,
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
+
+// Build returns a new assertion with a random "jti" claim.
+func (b *ClientAssertionBuilder) Build(now time.Time) (*Token, error) {
+	id, err := newRandomID()
+	if err != nil {
+		return nil, err
+	}
+
+	claims := &StandardClaims{
+		ID:        id,
+		Audience:  Audience{b.audience},
+		Issuer:    b.clientID,
+		Subject:   b.clientID,
+		ExpiresAt: NewNumericDate(now.Add(b.lifetime)),
+		IssuedAt:  NewNumericDate(now),
+	}
+	return b.builder.Build(claims)
+}
+
+// ClientKeyResolver returns a verifier for the key of the given client.
+// Header of the assertion can be used to select a key (by algorithm).
+type ClientKeyResolver func(clientID string, header Header) (Verifier, error)
+
+// ClientAssertionValidator validates JWT client assertions.
+// See: https://tools.ietf.org/html/rfc7523#section-3
+//
+type ClientAssertionValidator struct {
+	audience    string
+	resolver    ClientKeyResolver
+	store       ReplayStore
+	maxLifetime time.Duration
+}
+
+// ClientAssertionValidatorOption is used to configure a ClientAssertionValidator.
+type ClientAssertionValidatorOption func(*ClientAssertionValidator)
+
+// WithClientAssertionMaxLifetime rejects assertions that expire later than maxLifetime after now.
+// By default any "exp" claim in the future is accepted.
+func WithClientAssertionMaxLifetime(maxLifetime time.Duration) ClientAssertionValidatorOption {
+	return func(v *ClientAssertionValidator) {
+		v.maxLifetime = maxLifetime
+	}
+}
+
+// NewClientAssertionValidator returns a new ClientAssertionValidator.
+// Audience is the token endpoint URL or the issuer identifier of the authorization server.
+// Store is used to make assertions single-use, it can be nil if replays are detected elsewhere.
+func NewClientAssertionValidator(audience string, resolver ClientKeyResolver, store ReplayStore, opts ...ClientAssertionValidatorOption) *ClientAssertionValidator {
+	v := &ClientAssertionValidator{
+		audience: audience,
+		resolver: resolver,
+		store:    store,
+	}
+	for _, opt := range opts {
+		opt(v)
+	}
+	return v
+}
+
+// Validate checks the assertion and returns its claims, client ID is in Issuer and Subject claims.
+func (v *ClientAssertionValidator) Validate(assertion []byte, now time.Time) (*StandardClaims, error) {
+	token, err := Parse(assertion)
+	if err != nil {
+		return nil, err
+	}
+
+	var claims StandardClaims
+	if err := json.Unmarshal(token.RawClaims(), &claims); err != nil {
+		return nil, err
+	}
+	if claims.Issuer == "" || claims.Subject == "" || claims.ID == "" ||
+		len(claims.Audience) == 0 || claims.ExpiresAt == nil {
+		return nil, ErrMissingClaim
+	}
+	if !claims.IsSubject(claims.Issuer) {
+		return nil, ErrInvalidSubject
+	}
+
+	verifier, err := v.resolver(claims.Issuer, token.Header())
+	if err != nil {
+		return nil, err
+	}
+	if verifier == nil {
+		return nil, ErrInvalidKey
+	}
+	if token.Header().Algorithm != verifier.Algorithm() {
+		return nil, ErrAlgorithmMismatch
+	}
+	if err := verifier.Verify(token.Payload(), token.Signature()); err != nil {
+		return nil, err
+	}
+
+	if !claims.IsForAudience(v.audience) {
+		return nil, ErrInvalidAudience
+	}
+	if err := claims.validateAt(now); err != nil {
+		return nil, err
+	}
+	if v.maxLifetime > 0 && claims.ExpiresAt.After(now.Add(v.maxLifetime)) {
+		return nil, ErrTokenLifetimeTooLong
+	}
+
+	// jti is unique per client only
+	if v.store != nil {
+		if err := v.store.Store(claims.Issuer+"\x00"+claims.ID, claims.ExpiresAt.Time); err != nil {
+			return nil, err
+		}
+	}
+	return &claims, nil
+}
@@ -0,0 +1,126 @@
+package jwt
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestClientAssertion(t *testing.T) {
+	now := time.Now()
+	endpoint := "https://server.example.com/token"
+
+	resolver := func(clientID string, header Header) (Verifier, error) {
+		if clientID != "s6BhdRkqt3" {
+			return nil, errors.New("unknown client")
+		}
+		return NewVerifierES(ES256, ecdsaPublicKey256)
+	}
+	validator := NewClientAssertionValidator(endpoint, resolver, NewMemoryReplayStore(),
+		WithClientAssertionMaxLifetime(5*time.Minute))
+
+	signer := mustSigner(NewSignerES(ES256, ecdsaPrivateKey256))
+	assertion, err := NewClientAssertionBuilder(signer, "s6BhdRkqt3", endpoint).Build(now)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	claims, err := validator.Validate(assertion.Raw(), now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !claims.IsIssuer("s6BhdRkqt3") || !claims.IsSubject("s6BhdRkqt3") || claims.ID == "" {
+		t.Errorf("unexpected claims %#v", claims)
+	}
+	if got := claims.ExpiresAt.Sub(now); got > time.Minute {
+		t.Errorf("unexpected lifetime %v", got)
+	}
+
+	_, err = validator.Validate(assertion.Raw(), now)
+	if err != ErrTokenReplayed {
+		t.Errorf("want %#v, got %#v", ErrTokenReplayed, err)
+	}
+
+	// without a store replays are detected elsewhere
+	noStore := NewClientAssertionValidator(endpoint, resolver, nil)
+	for i := 0; i < 2; i++ {
+		if _, err := noStore.Validate(assertion.Raw(), now); err != nil {
+			t.Errorf("want nil, got %#v", err)
+		}
+	}
+}
+
+func TestClientAssertionInvalid(t *testing.T) {
+	now := time.Now()
+	endpoint := "https://server.example.com/token"
+
+	resolver := func(clientID string, header Header) (Verifier, error) {
+		return NewVerifierES(ES256, ecdsaPublicKey256)
+	}
+	validator := NewClientAssertionValidator(endpoint, resolver, NewMemoryReplayStore(),
+		WithClientAssertionMaxLifetime(5*time.Minute))
+
+	f := func(signer Signer, claims *StandardClaims, want error) {
+		t.Helper()
+
+		token, err := Build(signer, claims)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = validator.Validate(token.Raw(), now)
+		if err != want {
+			t.Errorf("want %#v, got %#v", want, err)
+		}
+	}
+
+	signer := mustSigner(NewSignerES(ES256, ecdsaPrivateKey256))
+	validClaims := func() *StandardClaims {
+		id, _ := newRandomID()
+		return &StandardClaims{
+			ID:        id,
+			Audience:  Audience{endpoint},
+			Issuer:    "client",
+			Subject:   "client",
+			ExpiresAt: NewNumericDate(now.Add(time.Minute)),
+		}
+	}
+
+	f(signer, validClaims(), nil)
+
+	c := validClaims()
+	c.ID = ""
+	f(signer, c, ErrMissingClaim)
+
+	c = validClaims()
+	c.Subject = "other"
+	f(signer, c, ErrInvalidSubject)
+
+	c = validClaims()
+	c.Audience = Audience{"https://other.example.com/token"}
+	f(signer, c, ErrInvalidAudience)
+
+	c = validClaims()
+	c.ExpiresAt = NewNumericDate(now.Add(-time.Minute))
+	f(signer, c, ErrTokenExpired)
+
+	c = validClaims()
+	c.ExpiresAt = NewNumericDate(now.Add(time.Hour))
+	f(signer, c, ErrTokenLifetimeTooLong)
+
+	f(mustSigner(NewSignerES(ES256, ecdsaOtherPrivateKey256)), validClaims(), ErrInvalidSignature)
+	f(mustSigner(NewSignerHS(HS256, []byte("key-0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWX"))), validClaims(), ErrAlgorithmMismatch)
+
+	// the same jti of another client
+	c = validClaims()
+	f(signer, c, nil)
+	c.Issuer, c.Subject = "other", "other"
+	f(signer, c, nil)
+	f(signer, c, ErrTokenReplayed)
+
+	// no key for the client
+	noKey := func(clientID string, header Header) (Verifier, error) {
+		return nil, nil
+	}
+	validator = NewClientAssertionValidator(endpoint, noKey, nil)
+	f(signer, validClaims(), ErrInvalidKey)
+}
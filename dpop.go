@@ -0,0 +1,310 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/sha256"
+	"encoding/json"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// DPoPType is a "typ" header value for DPoP proofs.
+const DPoPType = "dpop+jwt"
+
+// DPoPClaims represents claims of DPoP proof.
+// See: https://tools.ietf.org/html/rfc9449#section-4.2
+//
+type DPoPClaims struct {
+	// ID claim is a unique identifier of the proof.
+	ID string `json:"jti"`
+
+	// Method claim is the HTTP method of the request.
+	Method string `json:"htm"`
+
+	// URI claim is the HTTP target URI of the request, without query and fragment.
+	URI string `json:"htu"`
+
+	// IssuedAt claim is the creation time of the proof.
+	IssuedAt *NumericDate `json:"iat"`
+
+	// AccessTokenHash claim is the hash of the access token the proof is bound to.
+	AccessTokenHash string `json:"ath,omitempty"`
+
+	// Nonce claim is a value provided by the server.
+	Nonce string `json:"nonce,omitempty"`
+}
+
+// Confirmation represents "cnf" claim of the bound tokens.
+// See: https://tools.ietf.org/html/rfc7800#section-3.1
+//
+type Confirmation struct {
+	// JWK is a public key of the token holder.
+	JWK *JWK `json:"jwk,omitempty"`
+
+	// JWKThumbprint is SHA-256 JWK thumbprint of the DPoP key.
+	// See: https://tools.ietf.org/html/rfc9449#section-6.1
+	JWKThumbprint string `json:"jkt,omitempty"`
+}
+
+// DPoPBuilder is used to create DPoP proofs.
+type DPoPBuilder struct {
+	builder *Builder
+	jwk     *JWK
+}
+
+// NewDPoPBuilder returns a new DPoPBuilder.
+// Key is a public key of the signer, it's embedded into "jwk" header of the proofs.
+func NewDPoPBuilder(signer Signer, key crypto.PublicKey) (*DPoPBuilder, error) {
+	if !isAsymmetric(signer.Algorithm()) {
+		return nil, ErrUnsupportedAlg
+	}
+	jwk, err := NewJWK(key)
+	if err != nil {
+		return nil, err
+	}
+	b := &DPoPBuilder{
+		builder: NewBuilder(signer, WithType(DPoPType), WithJWK(jwk)),
+		jwk:     jwk,
+	}
+	return b, nil
+}
+
+// JWK returns a public key embedded into the proofs.
+func (b *DPoPBuilder) JWK() *JWK {
+	return b.jwk
+}
+
+// Build returns a new proof for the HTTP request with the given method and URI.
+// If accessToken is not empty, proof is bound to it with "ath" claim.
+func (b *DPoPBuilder) Build(method, uri, accessToken string, now time.Time) (*Token, error) {
+	return b.BuildWithNonce(method, uri, accessToken, "", now)
+}
+
+// BuildWithNonce is like Build but also sets a nonce provided by the server.
+func (b *DPoPBuilder) BuildWithNonce(method, uri, accessToken, nonce string, now time.Time) (*Token, error) {
+	htu, err := normalizeHTU(uri)
+	if err != nil {
+		return nil, err
+	}
+	id, err := newRandomID()
+	if err != nil {
+		return nil, err
+	}
+
+	claims := &DPoPClaims{
+		ID:       id,
+		Method:   method,
+		URI:      htu,
+		IssuedAt: NewNumericDate(now),
+		Nonce:    nonce,
+	}
+	if accessToken != "" {
+		claims.AccessTokenHash = accessTokenHashDPoP(accessToken)
+	}
+	return b.builder.Build(claims)
+}
+
+// DPoPProof represents a validated DPoP proof.
+type DPoPProof struct {
+	Claims DPoPClaims
+	JWK    *JWK
+}
+
+// Thumbprint returns SHA-256 JWK thumbprint of the proof key,
+// it is used as "jkt" confirmation in the bound tokens.
+func (p *DPoPProof) Thumbprint() (string, error) {
+	return p.JWK.ThumbprintKeyID(crypto.SHA256)
+}
+
+// DPoPValidator validates DPoP proofs.
+// See: https://tools.ietf.org/html/rfc9449#section-4.3
+//
+type DPoPValidator struct {
+	store  ReplayStore
+	maxAge time.Duration
+	leeway time.Duration
+}
+
+// DPoPOption is used to configure a DPoPValidator.
+type DPoPOption func(*DPoPValidator)
+
+// WithDPoPMaxAge sets how long the proof is accepted after its creation, default is 1 minute.
+func WithDPoPMaxAge(maxAge time.Duration) DPoPOption {
+	return func(v *DPoPValidator) {
+		v.maxAge = maxAge
+	}
+}
+
+// WithDPoPLeeway sets allowed clock skew for the proofs created in the future, default is 5 seconds.
+func WithDPoPLeeway(leeway time.Duration) DPoPOption {
+	return func(v *DPoPValidator) {
+		v.leeway = leeway
+	}
+}
+
+// NewDPoPValidator returns a new DPoPValidator.
+// Store is used to reject replayed proofs, it can be nil if replays are detected elsewhere.
+func NewDPoPValidator(store ReplayStore, opts ...DPoPOption) *DPoPValidator {
+	v := &DPoPValidator{
+		store:  store,
+		maxAge: time.Minute,
+		leeway: 5 * time.Second,
+	}
+	for _, opt := range opts {
+		opt(v)
+	}
+	return v
+}
+
+// Validate checks the proof of the HTTP request with the given method and URI.
+func (v *DPoPValidator) Validate(proof []byte, method, uri string, now time.Time) (*DPoPProof, error) {
+	p, err := v.validate(proof, method, uri, now)
+	if err != nil {
+		return nil, err
+	}
+	if err := v.storeID(p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// ValidateBound is like Validate but also checks that the proof
+// is bound to the access token and the access token is bound to the proof key.
+func (v *DPoPValidator) ValidateBound(proof []byte, method, uri, accessToken string, cnf *Confirmation, now time.Time) (*DPoPProof, error) {
+	p, err := v.validate(proof, method, uri, now)
+	if err != nil {
+		return nil, err
+	}
+
+	if !constTimeEqual(p.Claims.AccessTokenHash, accessTokenHashDPoP(accessToken)) {
+		return nil, ErrInvalidProof
+	}
+	thumbprint, err := p.Thumbprint()
+	if err != nil {
+		return nil, err
+	}
+	if cnf == nil || !constTimeEqual(cnf.JWKThumbprint, thumbprint) {
+		return nil, ErrInvalidProof
+	}
+
+	if err := v.storeID(p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (v *DPoPValidator) validate(proof []byte, method, uri string, now time.Time) (*DPoPProof, error) {
+	token, err := Parse(proof)
+	if err != nil {
+		return nil, err
+	}
+
+	header := token.Header()
+	if !isMediaType(header.Type, DPoPType) {
+		return nil, ErrInvalidType
+	}
+	if !isAsymmetric(header.Algorithm) {
+		return nil, ErrUnsupportedAlg
+	}
+	if header.JWK == nil || header.JWK.IsPrivate() {
+		return nil, ErrInvalidKey
+	}
+
+	key, err := header.JWK.PublicKey()
+	if err != nil {
+		return nil, err
+	}
+	verifier, err := NewVerifierForKey(header.Algorithm, key)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifier.Verify(token.Payload(), token.Signature()); err != nil {
+		return nil, err
+	}
+
+	var claims DPoPClaims
+	if err := json.Unmarshal(token.RawClaims(), &claims); err != nil {
+		return nil, err
+	}
+	if claims.ID == "" || claims.Method == "" || claims.URI == "" || claims.IssuedAt == nil {
+		return nil, ErrMissingClaim
+	}
+
+	htu, err := normalizeHTU(uri)
+	if err != nil {
+		return nil, err
+	}
+	claimHTU, err := normalizeHTU(claims.URI)
+	if err != nil || claims.Method != method || claimHTU != htu {
+		return nil, ErrInvalidProof
+	}
+
+	switch {
+	case claims.IssuedAt.After(now.Add(v.leeway)):
+		return nil, ErrTokenNotYetValid
+	case claims.IssuedAt.Add(v.maxAge).Before(now):
+		return nil, ErrTokenExpired
+	}
+
+	p := &DPoPProof{
+		Claims: claims,
+		JWK:    header.JWK,
+	}
+	return p, nil
+}
+
+// storeID stores ID of the proof with the key thumbprint,
+// so proofs of different keys don't collide.
+func (v *DPoPValidator) storeID(p *DPoPProof) error {
+	if v.store == nil {
+		return nil
+	}
+	thumbprint, err := p.Thumbprint()
+	if err != nil {
+		return err
+	}
+	return v.store.Store(thumbprint+"\x00"+p.Claims.ID, p.Claims.IssuedAt.Add(v.maxAge+v.leeway))
+}
+
+// normalizeHTU returns URI without query and fragment
+// with syntax-based normalization applied.
+// See: https://tools.ietf.org/html/rfc9449#section-4.3
+func normalizeHTU(uri string) (string, error) {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return "", ErrInvalidProof
+	}
+
+	scheme := strings.ToLower(u.Scheme)
+	host := strings.ToLower(u.Host)
+	switch {
+	case scheme == "https" && strings.HasSuffix(host, ":443"):
+		host = strings.TrimSuffix(host, ":443")
+	case scheme == "http" && strings.HasSuffix(host, ":80"):
+		host = strings.TrimSuffix(host, ":80")
+	}
+	path := u.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	return scheme + "://" + host + path, nil
+}
+
+func accessTokenHashDPoP(accessToken string) string {
+	digest := sha256.Sum256([]byte(accessToken))
+	return b64EncodeToString(digest[:])
+}
+
+// isAsymmetric reports whether alg is a public key algorithm, built-in or registered.
+func isAsymmetric(alg Algorithm) bool {
+	switch alg {
+	case EdDSA,
+		ES256, ES384, ES512,
+		PS256, PS384, PS512,
+		RS256, RS384, RS512:
+		return true
+	}
+	a, ok := lookupAlgorithm(alg)
+	return ok && a.info.KeyType != KeyTypeOct
+}
@@ -0,0 +1,193 @@
+package jwt
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestDPoP(t *testing.T) {
+	now := time.Now()
+	uri := "https://server.example.com/token"
+
+	f := func(signer Signer, key interface{}) {
+		t.Helper()
+
+		builder, err := NewDPoPBuilder(signer, key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		proof, err := builder.Build("POST", uri+"?query=1#fragment", "", now)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		validator := NewDPoPValidator(NewMemoryReplayStore())
+		p, err := validator.Validate(proof.Raw(), "POST", "HTTPS://Server.Example.COM:443/token", now.Add(time.Second))
+		if err != nil {
+			t.Fatalf("want nil, got %#v", err)
+		}
+		if p.Claims.URI != uri || p.Claims.AccessTokenHash != "" {
+			t.Errorf("unexpected claims %#v", p.Claims)
+		}
+
+		_, err = validator.Validate(proof.Raw(), "POST", uri, now)
+		if err != ErrTokenReplayed {
+			t.Errorf("want %#v, got %#v", ErrTokenReplayed, err)
+		}
+	}
+
+	edPub, edPriv, _ := ed25519.GenerateKey(rand.Reader)
+
+	f(mustSigner(NewSignerES(ES256, ecdsaPrivateKey256)), ecdsaPublicKey256)
+	f(mustSigner(NewSignerRS(RS256, rsaPrivateKey1)), rsaPublicKey1)
+	f(mustSigner(NewSignerPS(PS256, rsaPrivateKey1)), rsaPublicKey1)
+	f(mustSigner(NewSignerEdDSA(edPriv)), edPub)
+
+	_, err := NewDPoPBuilder(mustSigner(NewSignerHS(HS256, []byte("key-0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWX"))), ecdsaPublicKey256)
+	if err != ErrUnsupportedAlg {
+		t.Errorf("want %#v, got %#v", ErrUnsupportedAlg, err)
+	}
+	_, err = NewDPoPBuilder(NewSignerUnsafeNone(), ecdsaPublicKey256)
+	if err != ErrUnsupportedAlg {
+		t.Errorf("want %#v, got %#v", ErrUnsupportedAlg, err)
+	}
+}
+
+func TestDPoPReplayPerKey(t *testing.T) {
+	now := time.Now()
+	uri := "https://server.example.com/token"
+	validator := NewDPoPValidator(NewMemoryReplayStore())
+
+	f := func(signer Signer, jwk *JWK, want error) {
+		t.Helper()
+
+		claims := &DPoPClaims{ID: "same-id", Method: "POST", URI: uri, IssuedAt: NewNumericDate(now)}
+		proof, err := NewBuilder(signer, WithType(DPoPType), WithJWK(jwk)).Build(claims)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = validator.Validate(proof.Raw(), "POST", uri, now)
+		if err != want {
+			t.Errorf("want %#v, got %#v", want, err)
+		}
+	}
+
+	jwk, _ := NewJWK(ecdsaPublicKey256)
+	otherJWK, _ := NewJWK(ecdsaOtherPublicKey256)
+
+	f(mustSigner(NewSignerES(ES256, ecdsaPrivateKey256)), jwk, nil)
+	f(mustSigner(NewSignerES(ES256, ecdsaOtherPrivateKey256)), otherJWK, nil)
+	f(mustSigner(NewSignerES(ES256, ecdsaPrivateKey256)), jwk, ErrTokenReplayed)
+}
+
+func TestDPoPInvalid(t *testing.T) {
+	now := time.Now()
+	uri := "https://server.example.com/token"
+	signer := mustSigner(NewSignerES(ES256, ecdsaPrivateKey256))
+	builder, err := NewDPoPBuilder(signer, ecdsaPublicKey256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	validator := NewDPoPValidator(nil)
+
+	f := func(proof []byte, method, uri string, want error) {
+		t.Helper()
+
+		_, err := validator.Validate(proof, method, uri, now)
+		if err != want {
+			t.Errorf("want %#v, got %#v", want, err)
+		}
+	}
+
+	proof, _ := builder.Build("POST", uri, "", now)
+	f(proof.Raw(), "GET", uri, ErrInvalidProof)
+	f(proof.Raw(), "POST", "https://server.example.com/other", ErrInvalidProof)
+	f(proof.Raw(), "POST", "https://other.example.com/token", ErrInvalidProof)
+
+	proof, _ = builder.Build("POST", uri, "", now.Add(-time.Hour))
+	f(proof.Raw(), "POST", uri, ErrTokenExpired)
+
+	proof, _ = builder.Build("POST", uri, "", now.Add(time.Hour))
+	f(proof.Raw(), "POST", uri, ErrTokenNotYetValid)
+
+	// wrong type
+	token, _ := NewBuilder(signer, WithJWK(builder.JWK())).Build(&DPoPClaims{})
+	f(token.Raw(), "POST", uri, ErrInvalidType)
+
+	// no jwk
+	token, _ = NewBuilder(signer, WithType(DPoPType)).Build(&DPoPClaims{})
+	f(token.Raw(), "POST", uri, ErrInvalidKey)
+
+	// jwk of another key
+	otherJWK, _ := NewJWK(ecdsaOtherPublicKey256)
+	token, _ = NewBuilder(signer, WithType(DPoPType), WithJWK(otherJWK)).Build(&DPoPClaims{})
+	f(token.Raw(), "POST", uri, ErrInvalidSignature)
+
+	// missing claims
+	token, _ = NewBuilder(signer, WithType(DPoPType), WithJWK(builder.JWK())).Build(&DPoPClaims{})
+	f(token.Raw(), "POST", uri, ErrMissingClaim)
+
+	// symmetric algorithm
+	hsSigner := mustSigner(NewSignerHS(HS256, []byte("key-0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWX")))
+	token, _ = NewBuilder(hsSigner, WithType(DPoPType), WithJWK(builder.JWK())).Build(&DPoPClaims{})
+	f(token.Raw(), "POST", uri, ErrUnsupportedAlg)
+
+	// none algorithm
+	token, _ = NewBuilder(NewSignerUnsafeNone(), WithType(DPoPType), WithJWK(builder.JWK())).Build(&DPoPClaims{})
+	f(token.Raw(), "POST", uri, ErrUnsupportedAlg)
+}
+
+func TestDPoPBound(t *testing.T) {
+	now := time.Now()
+	uri := "https://resource.example.org/protectedresource"
+	accessToken := "Kz~8mXK1EalYznwH-LC-1fBAo.4Ljp~zsPE_NeO.gxU"
+
+	builder, err := NewDPoPBuilder(mustSigner(NewSignerES(ES256, ecdsaPrivateKey256)), ecdsaPublicKey256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	proof, err := builder.BuildWithNonce("GET", uri, accessToken, "eyJ7S_zG.eyJH0-Z.HX4w-7v", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var claims DPoPClaims
+	if err := json.Unmarshal(proof.RawClaims(), &claims); err != nil {
+		t.Fatal(err)
+	}
+	// see: https://tools.ietf.org/html/rfc9449#section-7.1
+	if claims.AccessTokenHash != "fUHyO2r2Z3DZ53EsNrWBb0xWXoaNy59IiKCAqksmQEo" {
+		t.Errorf("unexpected ath %#v", claims.AccessTokenHash)
+	}
+	if claims.Nonce != "eyJ7S_zG.eyJH0-Z.HX4w-7v" {
+		t.Errorf("unexpected nonce %#v", claims.Nonce)
+	}
+
+	validator := NewDPoPValidator(NewMemoryReplayStore())
+	p, err := validator.Validate(proof.Raw(), "GET", uri, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	jkt, err := p.Thumbprint()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f := func(accessToken string, cnf *Confirmation, want error) {
+		t.Helper()
+
+		validator := NewDPoPValidator(NewMemoryReplayStore())
+		_, err := validator.ValidateBound(proof.Raw(), "GET", uri, accessToken, cnf, now)
+		if err != want {
+			t.Errorf("want %#v, got %#v", want, err)
+		}
+	}
+
+	f(accessToken, &Confirmation{JWKThumbprint: jkt}, nil)
+	f("other-token", &Confirmation{JWKThumbprint: jkt}, ErrInvalidProof)
+	f(accessToken, &Confirmation{JWKThumbprint: "0ZcOCORZNYy-DWpqq30jZyJGHTN0d2HglBV3uiguA4I"}, ErrInvalidProof)
+	f(accessToken, nil, ErrInvalidProof)
+}
@@ -14,6 +14,13 @@ const (
 	// ErrInvalidKey indicates that key is not valid.
 	ErrInvalidKey = Error("jwt: key is not valid")
 
+	// ErrWeakKey indicates that key is weaker than allowed by the key policy.
+	ErrWeakKey = Error("jwt: key is too weak")
+
+	// ErrInvalidCurve indicates that ECDSA key curve doesn't match the algorithm
+	// or the public key isn't on the curve.
+	ErrInvalidCurve = Error("jwt: key curve is not valid")
+
 	// ErrUnsupportedAlg indicates that given algorithm is not supported.
 	ErrUnsupportedAlg = Error("jwt: algorithm is not supported")
 
@@ -31,4 +38,64 @@ const (
 
 	// ErrInvalidSignature indicates that signature is not valid.
 	ErrInvalidSignature = Error("jwt: signature is not valid")
+
+	// ErrUnsafeNone indicates that token is unsigned ("none" algorithm) and it isn't allowed.
+	ErrUnsafeNone = Error("jwt: unsigned token is not allowed")
+)
+
+// Validation errors.
+const (
+	// ErrInvalidType indicates that token type (typ header) is not valid.
+	ErrInvalidType = Error("jwt: token type is not valid")
+
+	// ErrMissingClaim indicates that a required claim is missing.
+	ErrMissingClaim = Error("jwt: required claim is missing")
+
+	// ErrUnexpectedClaim indicates that a claim is present but isn't allowed.
+	ErrUnexpectedClaim = Error("jwt: claim is not allowed")
+
+	// ErrInvalidIssuer indicates that token is issued by another issuer.
+	ErrInvalidIssuer = Error("jwt: token is issued by another issuer")
+
+	// ErrInvalidSubject indicates that token has another subject.
+	ErrInvalidSubject = Error("jwt: token subject is not valid")
+
+	// ErrInvalidAudience indicates that token is intended for another audience.
+	ErrInvalidAudience = Error("jwt: token is intended for another audience")
+
+	// ErrInvalidNonce indicates that token nonce doesn't match the request.
+	ErrInvalidNonce = Error("jwt: token nonce is not valid")
+
+	// ErrInvalidAuthTime indicates that end-user authentication is older than allowed.
+	ErrInvalidAuthTime = Error("jwt: authentication time is too old")
+
+	// ErrInvalidTokenHash indicates that at_hash or c_hash claim is missing or doesn't match.
+	ErrInvalidTokenHash = Error("jwt: token hash is not valid")
+
+	// ErrTokenExpired indicates that token is expired.
+	ErrTokenExpired = Error("jwt: token is expired")
+
+	// ErrTokenNotYetValid indicates that token cannot be used yet.
+	ErrTokenNotYetValid = Error("jwt: token is not valid yet")
+
+	// ErrTokenLifetimeTooLong indicates that token expires later than allowed.
+	ErrTokenLifetimeTooLong = Error("jwt: token lifetime is too long")
+
+	// ErrTokenReplayed indicates that token ID was already used.
+	ErrTokenReplayed = Error("jwt: token is replayed")
+
+	// ErrTokenRevoked indicates that token ID is revoked.
+	ErrTokenRevoked = Error("jwt: token is revoked")
+
+	// ErrTokenReused indicates that superseded refresh token is used again.
+	ErrTokenReused = Error("jwt: refresh token is reused")
+
+	// ErrInvalidCertChain indicates that "x5c" certificate chain is missing or not valid.
+	ErrInvalidCertChain = Error("jwt: certificate chain is not valid")
+
+	// ErrMissingRoots indicates that trusted roots for the certificate chain are not set.
+	ErrMissingRoots = Error("jwt: trusted roots are not set")
+
+	// ErrInvalidProof indicates that proof-of-possession is not valid.
+	ErrInvalidProof = Error("jwt: proof of possession is not valid")
 )
@@ -0,0 +1,343 @@
+package jwt
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// unmarshal decodes header from JSON.
+// Common headers are decoded by a hand-written scanner without allocations,
+// other headers are decoded with encoding/json.
+func (h *Header) unmarshal(data []byte) error {
+	prev := *h
+	*h = Header{}
+	if scanHeader(data, h, &prev) {
+		return nil
+	}
+
+	*h = Header{}
+	return json.Unmarshal(data, h)
+}
+
+// scanHeader decodes a flat JSON object with "alg", "typ", "cty" and "kid" string members.
+// Other members are skipped, strings of the previous header are reused if equal.
+// Returns false if data isn't supported by the scanner and must be decoded by encoding/json.
+func scanHeader(data []byte, h, prev *Header) bool {
+	s := headerScanner{data: data}
+
+	s.skipSpace()
+	if !s.consume('{') {
+		return false
+	}
+	s.skipSpace()
+	if s.consume('}') {
+		return s.end()
+	}
+
+	for {
+		s.skipSpace()
+		key, ok := s.simpleString()
+		if !ok {
+			return false
+		}
+		s.skipSpace()
+		if !s.consume(':') {
+			return false
+		}
+		s.skipSpace()
+
+		switch string(key) {
+		case "alg":
+			v, ok := s.simpleString()
+			if !ok {
+				return false
+			}
+			h.Algorithm = Algorithm(algorithmString(v, string(prev.Algorithm)))
+		case "typ":
+			v, ok := s.simpleString()
+			if !ok {
+				return false
+			}
+			h.Type = reuseString(v, prev.Type)
+		case "cty":
+			v, ok := s.simpleString()
+			if !ok {
+				return false
+			}
+			h.ContentType = reuseString(v, prev.ContentType)
+		case "kid":
+			v, ok := s.simpleString()
+			if !ok {
+				return false
+			}
+			h.KeyID = reuseString(v, prev.KeyID)
+		default:
+			// encoding/json matches keys case-insensitively and decodes "jwk"
+			if isHeaderKeyFold(key) || !s.skipValue(0) {
+				return false
+			}
+		}
+
+		s.skipSpace()
+		if s.consume(',') {
+			continue
+		}
+		if s.consume('}') {
+			return s.end()
+		}
+		return false
+	}
+}
+
+var headerKeys = [...][]byte{[]byte("alg"), []byte("typ"), []byte("cty"), []byte("kid"), []byte("jwk")}
+
+func isHeaderKeyFold(key []byte) bool {
+	for _, k := range headerKeys {
+		if bytes.EqualFold(key, k) {
+			return true
+		}
+	}
+	return false
+}
+
+func algorithmString(b []byte, prev string) string {
+	switch string(b) {
+	case "EdDSA":
+		return string(EdDSA)
+	case "HS256":
+		return string(HS256)
+	case "HS384":
+		return string(HS384)
+	case "HS512":
+		return string(HS512)
+	case "RS256":
+		return string(RS256)
+	case "RS384":
+		return string(RS384)
+	case "RS512":
+		return string(RS512)
+	case "ES256":
+		return string(ES256)
+	case "ES384":
+		return string(ES384)
+	case "ES512":
+		return string(ES512)
+	case "PS256":
+		return string(PS256)
+	case "PS384":
+		return string(PS384)
+	case "PS512":
+		return string(PS512)
+	default:
+		if a, ok := lookupAlgorithmBytes(b); ok {
+			return string(a.info.Algorithm)
+		}
+		return reuseString(b, prev)
+	}
+}
+
+func reuseString(b []byte, prev string) string {
+	switch {
+	case string(b) == prev:
+		return prev
+	case string(b) == "JWT":
+		return "JWT"
+	default:
+		return string(b)
+	}
+}
+
+// maxHeaderDepth limits nesting of skipped values.
+const maxHeaderDepth = 32
+
+type headerScanner struct {
+	data []byte
+	pos  int
+}
+
+func (s *headerScanner) end() bool {
+	s.skipSpace()
+	return s.pos == len(s.data)
+}
+
+func (s *headerScanner) skipSpace() {
+	for s.pos < len(s.data) {
+		switch s.data[s.pos] {
+		case ' ', '\t', '\n', '\r':
+			s.pos++
+		default:
+			return
+		}
+	}
+}
+
+func (s *headerScanner) consume(c byte) bool {
+	if s.pos < len(s.data) && s.data[s.pos] == c {
+		s.pos++
+		return true
+	}
+	return false
+}
+
+// simpleString returns content of a string without escapes and non-ASCII characters.
+func (s *headerScanner) simpleString() ([]byte, bool) {
+	if !s.consume('"') {
+		return nil, false
+	}
+	start := s.pos
+	for s.pos < len(s.data) {
+		c := s.data[s.pos]
+		switch {
+		case c == '"':
+			s.pos++
+			return s.data[start : s.pos-1], true
+		case c < 0x20 || c == '\\' || c >= 0x80:
+			return nil, false
+		}
+		s.pos++
+	}
+	return nil, false
+}
+
+// skipValue skips any valid JSON value.
+func (s *headerScanner) skipValue(depth int) bool {
+	if s.pos >= len(s.data) || depth > maxHeaderDepth {
+		return false
+	}
+
+	switch c := s.data[s.pos]; {
+	case c == '"':
+		return s.skipString()
+	case c == '{':
+		s.pos++
+		s.skipSpace()
+		if s.consume('}') {
+			return true
+		}
+		for {
+			s.skipSpace()
+			if !s.skipString() {
+				return false
+			}
+			s.skipSpace()
+			if !s.consume(':') {
+				return false
+			}
+			s.skipSpace()
+			if !s.skipValue(depth + 1) {
+				return false
+			}
+			s.skipSpace()
+			if s.consume(',') {
+				continue
+			}
+			return s.consume('}')
+		}
+	case c == '[':
+		s.pos++
+		s.skipSpace()
+		if s.consume(']') {
+			return true
+		}
+		for {
+			s.skipSpace()
+			if !s.skipValue(depth + 1) {
+				return false
+			}
+			s.skipSpace()
+			if s.consume(',') {
+				continue
+			}
+			return s.consume(']')
+		}
+	case c == 't':
+		return s.skipLiteral("true")
+	case c == 'f':
+		return s.skipLiteral("false")
+	case c == 'n':
+		return s.skipLiteral("null")
+	default:
+		return s.skipNumber()
+	}
+}
+
+func (s *headerScanner) skipLiteral(lit string) bool {
+	if len(s.data)-s.pos < len(lit) || string(s.data[s.pos:s.pos+len(lit)]) != lit {
+		return false
+	}
+	s.pos += len(lit)
+	return true
+}
+
+// skipString skips a string, escapes are validated, non-ASCII characters are not supported.
+func (s *headerScanner) skipString() bool {
+	if !s.consume('"') {
+		return false
+	}
+	for s.pos < len(s.data) {
+		c := s.data[s.pos]
+		s.pos++
+		switch {
+		case c == '"':
+			return true
+		case c < 0x20 || c >= 0x80:
+			return false
+		case c == '\\':
+			if s.pos >= len(s.data) {
+				return false
+			}
+			switch s.data[s.pos] {
+			case '"', '\\', '/', 'b', 'f', 'n', 'r', 't':
+				s.pos++
+			case 'u':
+				if len(s.data)-s.pos < 5 {
+					return false
+				}
+				for _, h := range s.data[s.pos+1 : s.pos+5] {
+					if !isHex(h) {
+						return false
+					}
+				}
+				s.pos += 5
+			default:
+				return false
+			}
+		}
+	}
+	return false
+}
+
+func (s *headerScanner) skipNumber() bool {
+	s.consume('-')
+	switch {
+	case s.consume('0'):
+	case s.digits() > 0:
+	default:
+		return false
+	}
+	if s.consume('.') && s.digits() == 0 {
+		return false
+	}
+	if s.consume('e') || s.consume('E') {
+		if !s.consume('+') {
+			s.consume('-')
+		}
+		if s.digits() == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *headerScanner) digits() int {
+	n := 0
+	for s.pos < len(s.data) && s.data[s.pos] >= '0' && s.data[s.pos] <= '9' {
+		s.pos++
+		n++
+	}
+	return n
+}
+
+func isHex(c byte) bool {
+	return '0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F'
+}
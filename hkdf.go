@@ -0,0 +1,35 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/hmac"
+)
+
+// deriveKeyHS derives a key of the hash size for the purpose from the secret.
+func deriveKeyHS(hash crypto.Hash, secret []byte, purpose string) []byte {
+	return hkdf(hash, secret, nil, []byte(purpose), hash.Size())
+}
+
+// hkdf returns a key derived with HMAC-based key derivation function.
+// See: https://tools.ietf.org/html/rfc5869
+func hkdf(hash crypto.Hash, secret, salt, info []byte, length int) []byte {
+	if len(salt) == 0 {
+		salt = make([]byte, hash.Size())
+	}
+	extract := hmac.New(hash.New, salt)
+	extract.Write(secret)
+	prk := extract.Sum(nil)
+
+	expand := hmac.New(hash.New, prk)
+	key := make([]byte, 0, length+hash.Size())
+	var t []byte
+	for counter := byte(1); len(key) < length; counter++ {
+		expand.Reset()
+		expand.Write(t)
+		expand.Write(info)
+		expand.Write([]byte{counter})
+		t = expand.Sum(t[:0])
+		key = append(key, t...)
+	}
+	return key[:length]
+}
@@ -0,0 +1,28 @@
+package jwt
+
+import (
+	"bytes"
+	"crypto"
+	"testing"
+)
+
+// See: https://tools.ietf.org/html/rfc5869#appendix-A
+func TestHKDF(t *testing.T) {
+	f := func(secret, salt, info []byte, length int, want string) {
+		t.Helper()
+
+		got := hkdf(crypto.SHA256, secret, salt, info, length)
+		if !bytes.Equal(got, mustHex(want)) {
+			t.Errorf("want %s, got %x", want, got)
+		}
+	}
+
+	secret := bytes.Repeat([]byte{0x0b}, 22)
+
+	f(secret, mustHex("000102030405060708090a0b0c"), mustHex("f0f1f2f3f4f5f6f7f8f9"), 42,
+		"3cb25f25faacd57a90434f64d0362f2a2d2d0a90cf1a5a4c5db02d56ecc4c5bf34007208d5b887185865",
+	)
+	f(secret, nil, nil, 42,
+		"8da4e775a563c18f715f802a063c5a31b8a11f5c5ee1879ec3454e5f3c738d2d9d201395faa4b61a96c8",
+	)
+}
@@ -0,0 +1,328 @@
+package jwt
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"math/big"
+
+	"github.com/cloudflare/circl/sign/ed448"
+)
+
+// JWK represents a public JSON Web Key.
+// See: https://tools.ietf.org/html/rfc7517
+//
+type JWK struct {
+	KeyType   string    `json:"kty"`
+	KeyID     string    `json:"kid,omitempty"`
+	Use       string    `json:"use,omitempty"`
+	Algorithm Algorithm `json:"alg,omitempty"`
+
+	// Curve is used by EC and OKP keys.
+	Curve string `json:"crv,omitempty"`
+	// X and Y are coordinates of EC keys, X is a public key for OKP keys.
+	X string `json:"x,omitempty"`
+	Y string `json:"y,omitempty"`
+
+	// N and E are modulus and exponent of RSA keys.
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+
+	// K is a key value of symmetric (oct) keys.
+	K string `json:"k,omitempty"`
+
+	// Pub is a public key of AKP keys.
+	Pub string `json:"pub,omitempty"`
+
+	// D and Priv are private key parameters, they must be empty for a public key.
+	D    string `json:"d,omitempty"`
+	Priv string `json:"priv,omitempty"`
+}
+
+// Key types and curves.
+// See: https://tools.ietf.org/html/rfc7518#section-6.1
+// See: https://tools.ietf.org/html/rfc8037#section-2
+// See: https://datatracker.ietf.org/doc/html/draft-ietf-cose-dilithium
+const (
+	KeyTypeEC  = "EC"
+	KeyTypeRSA = "RSA"
+	KeyTypeOKP = "OKP"
+	KeyTypeOct = "oct"
+	KeyTypeAKP = "AKP"
+
+	CurveP256    = "P-256"
+	CurveP384    = "P-384"
+	CurveP521    = "P-521"
+	CurveEd25519 = "Ed25519"
+	CurveEd448   = "Ed448"
+)
+
+var b64Decode = base64.RawURLEncoding.DecodeString
+
+// NewJWK returns a JWK for the given public key.
+// Supported keys are *rsa.PublicKey, *ecdsa.PublicKey, ed25519.PublicKey,
+// ed448.PublicKey, []byte for a symmetric key and keys of the registered algorithms.
+func NewJWK(key crypto.PublicKey) (*JWK, error) {
+	switch key := key.(type) {
+	case []byte:
+		if len(key) == 0 {
+			return nil, ErrInvalidKey
+		}
+		return &JWK{
+			KeyType: KeyTypeOct,
+			K:       b64EncodeToString(key),
+		}, nil
+
+	case *rsa.PublicKey:
+		if key == nil {
+			return nil, ErrInvalidKey
+		}
+		return &JWK{
+			KeyType: KeyTypeRSA,
+			N:       b64EncodeToString(key.N.Bytes()),
+			E:       b64EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}, nil
+
+	case *ecdsa.PublicKey:
+		if key == nil {
+			return nil, ErrInvalidKey
+		}
+		crv, ok := getCurveName(key.Curve)
+		if !ok {
+			return nil, ErrInvalidKey
+		}
+		size := roundBytes(key.Params().BitSize)
+		return &JWK{
+			KeyType: KeyTypeEC,
+			Curve:   crv,
+			X:       b64EncodeToString(padBytes(key.X.Bytes(), size)),
+			Y:       b64EncodeToString(padBytes(key.Y.Bytes(), size)),
+		}, nil
+
+	case ed25519.PublicKey:
+		if len(key) != ed25519.PublicKeySize {
+			return nil, ErrInvalidKey
+		}
+		return &JWK{
+			KeyType: KeyTypeOKP,
+			Curve:   CurveEd25519,
+			X:       b64EncodeToString(key),
+		}, nil
+
+	case ed448.PublicKey:
+		if len(key) != ed448.PublicKeySize {
+			return nil, ErrInvalidKey
+		}
+		return &JWK{
+			KeyType: KeyTypeOKP,
+			Curve:   CurveEd448,
+			X:       b64EncodeToString(key),
+		}, nil
+
+	default:
+		return newRegisteredJWK(key)
+	}
+}
+
+// PublicKey returns a public key represented by the JWK.
+// For symmetric keys it returns an error.
+// Keys of registered algorithms are selected by key type, curve and "alg" if it's set.
+func (k *JWK) PublicKey() (crypto.PublicKey, error) {
+	switch k.KeyType {
+	case KeyTypeRSA:
+		n, errN := b64Decode(k.N)
+		e, errE := b64Decode(k.E)
+		if errN != nil || errE != nil || len(n) == 0 || len(e) == 0 || len(e) > 4 {
+			return nil, ErrInvalidKey
+		}
+		return &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}, nil
+
+	case KeyTypeEC:
+		curve, ok := getCurve(k.Curve)
+		if !ok {
+			return nil, ErrInvalidKey
+		}
+		size := roundBytes(curve.Params().BitSize)
+		x, errX := b64Decode(k.X)
+		y, errY := b64Decode(k.Y)
+		if errX != nil || errY != nil || len(x) != size || len(y) != size {
+			return nil, ErrInvalidKey
+		}
+		key := &ecdsa.PublicKey{
+			Curve: curve,
+			X:     new(big.Int).SetBytes(x),
+			Y:     new(big.Int).SetBytes(y),
+		}
+		if !curve.IsOnCurve(key.X, key.Y) {
+			return nil, ErrInvalidKey
+		}
+		return key, nil
+
+	case KeyTypeOKP:
+		x, err := b64Decode(k.X)
+		if err != nil {
+			return nil, ErrInvalidKey
+		}
+		switch {
+		case k.Curve == CurveEd25519 && len(x) == ed25519.PublicKeySize:
+			return ed25519.PublicKey(x), nil
+		case k.Curve == CurveEd448 && len(x) == ed448.PublicKeySize:
+			return ed448.PublicKey(x), nil
+		default:
+			return registeredPublicKey(k)
+		}
+
+	default:
+		return registeredPublicKey(k)
+	}
+}
+
+// IsPrivate reports whether the JWK contains private key parameters.
+func (k *JWK) IsPrivate() bool {
+	return k.D != "" || k.Priv != ""
+}
+
+// Thumbprint returns JWK thumbprint computed with the given hash.
+// It's computed from the decoded key, so different encodings of the same key
+// (e.g. leading zeros of RSA modulus) have the same thumbprint.
+// See: https://tools.ietf.org/html/rfc7638
+func (k *JWK) Thumbprint(hash crypto.Hash) ([]byte, error) {
+	if !hash.Available() {
+		return nil, ErrUnsupportedAlg
+	}
+	jwk, err := k.canonical()
+	if err != nil {
+		return nil, err
+	}
+
+	// only required members in lexicographic order, see RFC 7638 section 3.2
+	var buf bytes.Buffer
+	switch jwk.KeyType {
+	case KeyTypeRSA:
+		buf.WriteString(`{"e":"` + jwk.E + `","kty":"RSA","n":"` + jwk.N + `"}`)
+	case KeyTypeEC:
+		buf.WriteString(`{"crv":"` + jwk.Curve + `","kty":"EC","x":"` + jwk.X + `","y":"` + jwk.Y + `"}`)
+	case KeyTypeOKP:
+		buf.WriteString(`{"crv":"` + jwk.Curve + `","kty":"OKP","x":"` + jwk.X + `"}`)
+	case KeyTypeOct:
+		buf.WriteString(`{"k":"` + jwk.K + `","kty":"oct"}`)
+	default:
+		members, err := registeredThumbprint(jwk)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(members)
+	}
+	return hashPayload(hash, buf.Bytes())
+}
+
+// canonical returns the JWK re-encoded from its decoded key.
+func (k *JWK) canonical() (*JWK, error) {
+	if k.KeyType == KeyTypeOct {
+		key, err := b64Decode(k.K)
+		if err != nil {
+			return nil, ErrInvalidKey
+		}
+		return NewJWK(key)
+	}
+	key, err := k.PublicKey()
+	if err != nil {
+		return nil, err
+	}
+	return NewJWK(key)
+}
+
+// ThumbprintKeyID returns base64url encoded JWK thumbprint to be used as a key ID.
+func (k *JWK) ThumbprintKeyID(hash crypto.Hash) (string, error) {
+	thumbprint, err := k.Thumbprint(hash)
+	if err != nil {
+		return "", err
+	}
+	return b64EncodeToString(thumbprint), nil
+}
+
+// ThumbprintURI returns JWK thumbprint URI.
+// See: https://tools.ietf.org/html/rfc9278
+func (k *JWK) ThumbprintURI(hash crypto.Hash) (string, error) {
+	name, ok := hashNames[hash]
+	if !ok {
+		return "", ErrUnsupportedAlg
+	}
+	thumbprint, err := k.ThumbprintKeyID(hash)
+	if err != nil {
+		return "", err
+	}
+	return "urn:ietf:params:oauth:jwk-thumbprint:" + name + ":" + thumbprint, nil
+}
+
+// hashNames are hash names from IANA "Named Information Hash Algorithm" registry.
+var hashNames = map[crypto.Hash]string{
+	crypto.SHA256: "sha-256",
+	crypto.SHA384: "sha-384",
+	crypto.SHA512: "sha-512",
+}
+
+// HashName returns the name of the hash in IANA "Named Information Hash Algorithm" registry,
+// like "sha-256". SHA-256, SHA-384 and SHA-512 are supported.
+func HashName(hash crypto.Hash) (string, bool) {
+	name, ok := hashNames[hash]
+	return name, ok
+}
+
+// HashByName returns the hash with the given name in IANA "Named Information Hash Algorithm" registry.
+func HashByName(name string) (crypto.Hash, bool) {
+	for hash, n := range hashNames {
+		if n == name {
+			return hash, true
+		}
+	}
+	return 0, false
+}
+
+func getCurveName(curve elliptic.Curve) (string, bool) {
+	switch curve {
+	case elliptic.P256():
+		return CurveP256, true
+	case elliptic.P384():
+		return CurveP384, true
+	case elliptic.P521():
+		return CurveP521, true
+	default:
+		return "", false
+	}
+}
+
+func getCurve(name string) (elliptic.Curve, bool) {
+	switch name {
+	case CurveP256:
+		return elliptic.P256(), true
+	case CurveP384:
+		return elliptic.P384(), true
+	case CurveP521:
+		return elliptic.P521(), true
+	default:
+		return nil, false
+	}
+}
+
+func b64EncodeToString(src []byte) string {
+	buf := make([]byte, b64EncodedLen(len(src)))
+	b64Encode(buf, src)
+	return string(buf)
+}
+
+func padBytes(b []byte, size int) []byte {
+	if len(b) >= size {
+		return b
+	}
+	padded := make([]byte, size)
+	copy(padded[size-len(b):], b)
+	return padded
+}
@@ -0,0 +1,201 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/cloudflare/circl/sign/ed448"
+)
+
+func TestJWK(t *testing.T) {
+	f := func(key crypto.PublicKey, kty string) {
+		t.Helper()
+
+		jwk, err := NewJWK(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if jwk.KeyType != kty {
+			t.Errorf("want %#v, got %#v", kty, jwk.KeyType)
+		}
+
+		raw, err := json.Marshal(jwk)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var newJWK JWK
+		if err := json.Unmarshal(raw, &newJWK); err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := newJWK.PublicKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, key) {
+			t.Errorf("want %#v, got %#v", key, got)
+		}
+	}
+
+	edPub, _, _ := ed25519.GenerateKey(rand.Reader)
+	ed448Pub, _, _ := ed448.GenerateKey(rand.Reader)
+
+	f(rsaPublicKey1, KeyTypeRSA)
+	f(ecdsaPublicKey256, KeyTypeEC)
+	f(ecdsaPublicKey384, KeyTypeEC)
+	f(ecdsaPublicKey521, KeyTypeEC)
+	f(edPub, KeyTypeOKP)
+	f(ed448Pub, KeyTypeOKP)
+}
+
+func TestJWKBadParams(t *testing.T) {
+	f := func(jwk *JWK) {
+		t.Helper()
+
+		if _, err := jwk.PublicKey(); err == nil {
+			t.Error("want err, got nil")
+		}
+	}
+
+	f(&JWK{})
+	f(&JWK{KeyType: KeyTypeRSA, N: "AQAB"})
+	f(&JWK{KeyType: KeyTypeEC, Curve: "P-100", X: "AQAB", Y: "AQAB"})
+	f(&JWK{KeyType: KeyTypeEC, Curve: CurveP256, X: "AQAB", Y: "AQAB"})
+	f(&JWK{KeyType: KeyTypeOKP, Curve: "X25519", X: "AQAB"})
+	f(&JWK{KeyType: KeyTypeOKP, Curve: CurveEd25519, X: "AQAB"})
+	f(&JWK{KeyType: KeyTypeOKP, Curve: CurveEd448, X: "AQAB"})
+	f(&JWK{KeyType: KeyTypeOKP, Curve: CurveEd448, X: "11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo"})
+
+	if _, err := NewJWK("key"); err == nil {
+		t.Error("want err, got nil")
+	}
+}
+
+func TestJWKThumbprint(t *testing.T) {
+	// see: https://tools.ietf.org/html/rfc7638#section-3.1
+	jwk := &JWK{
+		KeyType:   KeyTypeRSA,
+		KeyID:     "2011-04-29",
+		Algorithm: RS256,
+		N:         "0vx7agoebGcQSuuPiLJXZptN9nndrQmbXEps2aiAFbWhM78LhWx4cbbfAAtVT86zwu1RK7aPFFxuhDR1L6tSoc_BJECPebWKRXjBZCiFV4n3oknjhMstn64tZ_2W-5JsGY4Hc5n9yBXArwl93lqt7_RN5w6Cf0h4QyQ5v-65YGjQR0_FDW2QvzqY368QQMicAtaSqzs8KJZgnYb9c7d0zgdAZHzu6qMQvRL5hajrn1n91CbOpbISD08qNLyrdkt-bFTWhAI4vMQFh6WeZu0fM4lFd2NcRwr3XPksINHaQ-G_xBniIqbw0Ls1jF44-csFCur-kEgU8awapJzKnqDKgw",
+		E:         "AQAB",
+	}
+
+	thumbprint, err := jwk.Thumbprint(crypto.SHA256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "NzbLsXh8uDCcd-6MNwXF4W_7noWXFZAfHkxZsRGC9Xs"
+	if got := b64EncodeToString(thumbprint); got != want {
+		t.Errorf("want %#v, got %#v", want, got)
+	}
+
+	// see: https://tools.ietf.org/html/rfc9278#section-3
+	uri, err := jwk.ThumbprintURI(crypto.SHA256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "urn:ietf:params:oauth:jwk-thumbprint:sha-256:" + want; uri != want {
+		t.Errorf("want %#v, got %#v", want, uri)
+	}
+}
+
+func TestJWKThumbprintKeyTypes(t *testing.T) {
+	f := func(key interface{}, hash crypto.Hash, want string) {
+		t.Helper()
+
+		jwk, err := NewJWK(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := jwk.ThumbprintKeyID(hash)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Errorf("want %#v, got %#v", want, got)
+		}
+	}
+
+	edPub := ed25519.PublicKey(mustDecode("11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo"))
+
+	// see: https://tools.ietf.org/html/rfc8037#appendix-A.3
+	f(edPub, crypto.SHA256, "kPrK_qmxVWaYVA9wwBF6Iuo3vVzz7TxHCTwXBygrS4k")
+	f([]byte("secret"), crypto.SHA256, "DWBh0SEIAPYh1x5uvot4z3AhaikHkxNJa3Ada2fT-Cg")
+
+	jwk, _ := NewJWK(edPub)
+	if _, err := jwk.ThumbprintURI(crypto.MD5); err == nil {
+		t.Error("want err, got nil")
+	}
+	if _, err := (&JWK{KeyType: KeyTypeOct}).Thumbprint(crypto.SHA256); err == nil {
+		t.Error("want err, got nil")
+	}
+}
+
+func TestHashName(t *testing.T) {
+	f := func(hash crypto.Hash, name string) {
+		t.Helper()
+
+		got, ok := HashName(hash)
+		if !ok || got != name {
+			t.Errorf("want %#v, got %#v", name, got)
+		}
+		back, ok := HashByName(name)
+		if !ok || back != hash {
+			t.Errorf("want %v, got %v", hash, back)
+		}
+	}
+
+	f(crypto.SHA256, "sha-256")
+	f(crypto.SHA384, "sha-384")
+	f(crypto.SHA512, "sha-512")
+
+	if _, ok := HashName(crypto.MD5); ok {
+		t.Error("want unsupported md5")
+	}
+	if _, ok := HashByName("sha-1"); ok {
+		t.Error("want unsupported sha-1")
+	}
+}
+
+func TestJWKThumbprintCanonical(t *testing.T) {
+	f := func(jwk, other *JWK) {
+		t.Helper()
+
+		want, err := jwk.ThumbprintKeyID(crypto.SHA256)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := other.ThumbprintKeyID(crypto.SHA256)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Errorf("want %#v, got %#v", want, got)
+		}
+	}
+
+	rsaJWK, _ := NewJWK(rsaPublicKey1)
+	n := append([]byte{0}, mustDecode(rsaJWK.N)...)
+	f(rsaJWK, &JWK{KeyType: KeyTypeRSA, N: b64EncodeToString(n), E: "AAEAAQ"})
+
+	// non-zero trailing bits are ignored by the decoder
+	edJWK := &JWK{KeyType: KeyTypeOKP, Curve: CurveEd25519, X: "11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo"}
+	f(edJWK, &JWK{KeyType: KeyTypeOKP, Curve: CurveEd25519, X: "11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURp"})
+
+	if _, err := (&JWK{KeyType: KeyTypeOKP, Curve: CurveEd25519, X: "AQAB"}).Thumbprint(crypto.SHA256); err == nil {
+		t.Error("want err, got nil")
+	}
+}
+
+func mustDecode(s string) []byte {
+	b, err := b64Decode(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
@@ -0,0 +1,49 @@
+package jwt
+
+// JWKSet represents a set of public keys, e.g. published by an issuer.
+// See: https://tools.ietf.org/html/rfc7517#section-5
+//
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+// Key returns a key with the given key ID.
+func (s *JWKSet) Key(kid string) (*JWK, bool) {
+	for i := range s.Keys {
+		if s.Keys[i].KeyID == kid {
+			return &s.Keys[i], true
+		}
+	}
+	return nil, false
+}
+
+// Verifier returns a verifier for the token header with the first matching key of the set.
+// Key is selected by "kid" header when it's set, keys with another "alg" or "use" than "sig"
+// are skipped, other keys are tried in order until NewVerifierForKey accepts one.
+// Keys of the registered algorithms are supported too, symmetric keys are skipped.
+// If no key is accepted, the error of the last tried key is returned, ErrInvalidKey if none was tried.
+func (s *JWKSet) Verifier(header Header, opts ...VerifierOption) (Verifier, error) {
+	var lastErr error = ErrInvalidKey
+	for i := range s.Keys {
+		k := &s.Keys[i]
+		switch {
+		case header.KeyID != "" && k.KeyID != header.KeyID:
+		case k.Algorithm != "" && k.Algorithm != header.Algorithm:
+		case k.Use != "" && k.Use != "sig":
+		case k.KeyType == KeyTypeOct:
+		default:
+			key, err := k.PublicKey()
+			if err != nil {
+				lastErr = err
+				continue
+			}
+			verifier, err := NewVerifierForKey(header.Algorithm, key, opts...)
+			if err != nil {
+				lastErr = err
+				continue
+			}
+			return verifier, nil
+		}
+	}
+	return nil, lastErr
+}
@@ -0,0 +1,97 @@
+package jwt
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"testing"
+)
+
+func TestJWKSet(t *testing.T) {
+	registerTestAlgorithm()
+
+	_, edKey, _ := ed25519.GenerateKey(rand.Reader)
+	testKey := &testPrivateKey{key: edKey}
+
+	newJWK := func(key interface{}, kid string) JWK {
+		jwk, err := NewJWK(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		jwk.KeyID = kid
+		return *jwk
+	}
+
+	encJWK := newJWK(&rsaPrivateKey2.PublicKey, "enc")
+	encJWK.Use = "enc"
+	psJWK := newJWK(rsaPublicKey1, "ps")
+	psJWK.Algorithm = PS256
+
+	set := &JWKSet{
+		Keys: []JWK{
+			encJWK,
+			psJWK,
+			newJWK(rsaPublicKey1, "rs"),
+			newJWK(ecdsaPublicKey256, "es"),
+			newJWK(testKey.Public(), "test"),
+			{KeyType: KeyTypeOct, KeyID: "oct", K: "c2VjcmV0"},
+		},
+	}
+	raw, err := json.Marshal(set)
+	if err != nil {
+		t.Fatal(err)
+	}
+	set = &JWKSet{}
+	if err := json.Unmarshal(raw, set); err != nil {
+		t.Fatal(err)
+	}
+
+	f := func(signer Signer, kid string, want error) {
+		t.Helper()
+
+		token, err := Build(signer, &StandardClaims{ID: "id"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		header := token.Header()
+		header.KeyID = kid
+
+		verifier, err := set.Verifier(header)
+		if err != want {
+			t.Fatalf("%s %q: want %v, got %v", header.Algorithm, kid, want, err)
+		}
+		if err != nil {
+			return
+		}
+		if err := verifier.Verify(token.Payload(), token.Signature()); err != nil {
+			t.Errorf("%s %q: want nil, got %v", header.Algorithm, kid, err)
+		}
+	}
+
+	rsSigner := mustSigner(NewSignerRS(RS256, rsaPrivateKey1))
+	psSigner := mustSigner(NewSignerPS(PS256, rsaPrivateKey1))
+	esSigner := mustSigner(NewSignerES(ES256, ecdsaPrivateKey256))
+	testSigner := mustSigner(NewSignerForKey(testAlg, testKey))
+
+	f(rsSigner, "rs", nil)
+	f(rsSigner, "", nil)
+	f(psSigner, "ps", nil)
+	f(psSigner, "", nil)
+	f(esSigner, "es", nil)
+	f(esSigner, "", nil)
+	f(testSigner, "test", nil)
+	f(testSigner, "", nil)
+
+	f(rsSigner, "ps", ErrInvalidKey)
+	f(rsSigner, "enc", ErrInvalidKey)
+	f(rsSigner, "unknown", ErrInvalidKey)
+	f(esSigner, "rs", ErrUnsupportedAlg)
+	f(mustSigner(NewSignerHS(HS256, []byte("key-0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWX"))), "oct", ErrInvalidKey)
+
+	if k, ok := set.Key("es"); !ok || k.KeyType != KeyTypeEC {
+		t.Errorf("want EC key, got %#v", k)
+	}
+	if _, ok := set.Key("unknown"); ok {
+		t.Error("want no key")
+	}
+}
@@ -3,6 +3,7 @@ package jwt
 import (
 	"bytes"
 	"encoding/json"
+	"strings"
 )
 
 // Token represents a JWT token.
@@ -15,6 +16,7 @@ type Token struct {
 	signature []byte
 	header    Header
 	claims    json.RawMessage
+	buf       []byte
 }
 
 func (t *Token) String() string {
@@ -64,23 +66,57 @@ type Header struct {
 	Algorithm   Algorithm `json:"alg"`
 	Type        string    `json:"typ,omitempty"` // only "JWT" can be here
 	ContentType string    `json:"cty,omitempty"`
+	KeyID       string    `json:"kid,omitempty"`
+
+	// JWK is a public key that corresponds to the key used to sign the token.
+	// See: https://tools.ietf.org/html/rfc7515#section-4.1.3
+	JWK *JWK `json:"jwk,omitempty"`
 }
 
 // MarshalJSON implements the json.Marshaler interface.
 func (h *Header) MarshalJSON() ([]byte, error) {
 	buf := bytes.Buffer{}
-	buf.WriteString(`{"alg":"`)
-	buf.WriteString(string(h.Algorithm))
+	buf.WriteString(`{"alg":`)
+	writeJSONString(&buf, string(h.Algorithm))
 
 	if h.Type != "" {
-		buf.WriteString(`","typ":"`)
-		buf.WriteString(h.Type)
+		buf.WriteString(`,"typ":`)
+		writeJSONString(&buf, h.Type)
 	}
 	if h.ContentType != "" {
-		buf.WriteString(`","cty":"`)
-		buf.WriteString(h.ContentType)
+		buf.WriteString(`,"cty":`)
+		writeJSONString(&buf, h.ContentType)
+	}
+	if h.KeyID != "" {
+		buf.WriteString(`,"kid":`)
+		writeJSONString(&buf, h.KeyID)
 	}
-	buf.WriteString(`"}`)
+	if h.JWK != nil {
+		jwk, err := json.Marshal(h.JWK)
+		if err != nil {
+			return nil, err
+		}
+		buf.WriteString(`,"jwk":`)
+		buf.Write(jwk)
+	}
+	buf.WriteByte('}')
 
 	return buf.Bytes(), nil
 }
+
+// writeJSONString writes s as a quoted and escaped JSON string.
+func writeJSONString(buf *bytes.Buffer, s string) {
+	// returned err is always nil for a string
+	b, _ := json.Marshal(s)
+	buf.Write(b)
+}
+
+// isMediaType reports whether typ header is equal to the given media type,
+// "application/" prefix is optional and comparison is case insensitive.
+// See: https://tools.ietf.org/html/rfc7515#section-4.1.9
+func isMediaType(typ, mediaType string) bool {
+	if len(typ) > len("application/") && strings.EqualFold(typ[:len("application/")], "application/") {
+		typ = typ[len("application/"):]
+	}
+	return strings.EqualFold(typ, mediaType)
+}
@@ -114,8 +114,36 @@ func BenchmarkAlgRS(b *testing.B) {
 	}
 }
 
+func BenchmarkAlgMLDSA(b *testing.B) {
+	mldsaAlgos := []jwt.Algorithm{jwt.MLDSA44, jwt.MLDSA65, jwt.MLDSA87}
+	for _, algo := range mldsaAlgos {
+		pubKey, seed, keyErr := jwt.GenerateMLDSAKey(algo)
+		if keyErr == jwt.ErrUnsupportedAlg {
+			b.Skip("ML-DSA requires Go 1.27")
+		}
+		if keyErr != nil {
+			b.Fatal(keyErr)
+		}
+		signer, signerErr := jwt.NewSignerMLDSA(algo, seed)
+		if signerErr != nil {
+			b.Fatal(signerErr)
+		}
+		verifier, verifierErr := jwt.NewVerifierMLDSA(algo, pubKey)
+		if verifierErr != nil {
+			b.Fatal(verifierErr)
+		}
+		builder := jwt.NewBuilder(signer)
+		b.Run("Sign-"+string(algo), func(b *testing.B) {
+			runSignerBench(b, builder)
+		})
+		b.Run("Verify-"+string(algo), func(b *testing.B) {
+			runVerifyBench(b, builder, verifier)
+		})
+	}
+}
+
 func BenchmarkAlgHS(b *testing.B) {
-	key := []byte("12345")
+	key := []byte("12345-0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUV")
 	hsAlgos := []jwt.Algorithm{jwt.HS256, jwt.HS384, jwt.HS512}
 	for _, algo := range hsAlgos {
 		signer, signerErr := jwt.NewSignerHS(algo, key)
@@ -187,3 +215,199 @@ func runVerifyBench(b *testing.B, builder *jwt.Builder, verifier jwt.Verifier) {
 		b.Log(sink)
 	}
 }
+
+func BenchmarkParse(b *testing.B) {
+	signer, signerErr := jwt.NewSignerHS(jwt.HS256, []byte("12345-0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUV"))
+	if signerErr != nil {
+		b.Fatal(signerErr)
+	}
+	token, tokenErr := jwt.NewBuilder(signer).Build(jwt.StandardClaims{
+		ID:       "id",
+		Issuer:   "sdf",
+		IssuedAt: jwt.NewNumericDate(time.Now()),
+	})
+	if tokenErr != nil {
+		b.Fatal(tokenErr)
+	}
+	raw := token.Raw()
+
+	b.Run("Parse", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := jwt.Parse(raw); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("ParseInto", func(b *testing.B) {
+		b.ReportAllocs()
+		var tk jwt.Token
+		for i := 0; i < b.N; i++ {
+			if err := jwt.ParseInto(&tk, raw); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkAppendBuild(b *testing.B) {
+	_, edKey, keyErr := ed25519.GenerateKey(rand.Reader)
+	if keyErr != nil {
+		b.Fatal(keyErr)
+	}
+	edSigner, edErr := jwt.NewSignerEdDSA(edKey)
+	if edErr != nil {
+		b.Fatal(edErr)
+	}
+	hsSigner, hsErr := jwt.NewSignerHS(jwt.HS256, []byte("12345-0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUV"))
+	if hsErr != nil {
+		b.Fatal(hsErr)
+	}
+	var claims interface{} = jwt.EncodedClaims("eyJqdGkiOiJpZCIsImlzcyI6InNkZiJ9")
+
+	for _, signer := range []jwt.Signer{hsSigner, edSigner} {
+		builder := jwt.NewBuilder(signer)
+		b.Run(string(signer.Algorithm()), func(b *testing.B) {
+			b.ReportAllocs()
+			var buf []byte
+			var err error
+			for i := 0; i < b.N; i++ {
+				buf, err = builder.AppendBuild(buf[:0], claims)
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkBuildLargeClaims(b *testing.B) {
+	signer, signerErr := jwt.NewSignerHS(jwt.HS256, []byte("12345-0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUV"))
+	if signerErr != nil {
+		b.Fatal(signerErr)
+	}
+	builder := jwt.NewBuilder(signer)
+
+	entitlements := make([]string, 2000)
+	for i := range entitlements {
+		entitlements[i] = "entitlement-" + string(rune('a'+i%26))
+	}
+	claims := &jwt.AccessTokenClaims{
+		StandardClaims: jwt.StandardClaims{ID: "id", Issuer: "sdf"},
+		Entitlements:   entitlements,
+	}
+
+	b.Run("Build", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := builder.Build(claims); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("AppendBuild", func(b *testing.B) {
+		b.ReportAllocs()
+		var buf []byte
+		var err error
+		for i := 0; i < b.N; i++ {
+			buf, err = builder.AppendBuild(buf[:0], claims)
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	// claims are written to the hash while they're encoded
+	key, keyErr := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if keyErr != nil {
+		b.Fatal(keyErr)
+	}
+	esSigner, signerErr := jwt.NewSignerES(jwt.ES256, key)
+	if signerErr != nil {
+		b.Fatal(signerErr)
+	}
+	esBuilder := jwt.NewBuilder(esSigner)
+
+	b.Run("AppendBuildES256", func(b *testing.B) {
+		b.ReportAllocs()
+		var buf []byte
+		var err error
+		for i := 0; i < b.N; i++ {
+			buf, err = esBuilder.AppendBuild(buf[:0], claims)
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkVerifyBatch(b *testing.B) {
+	_, edKey, _ := ed25519.GenerateKey(rand.Reader)
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	hsKey := []byte("12345-0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUV")
+
+	type family struct {
+		signer   func() (jwt.Signer, error)
+		verifier func() (jwt.Verifier, error)
+	}
+	families := []family{
+		{
+			func() (jwt.Signer, error) { return jwt.NewSignerHS(jwt.HS256, hsKey) },
+			func() (jwt.Verifier, error) { return jwt.NewVerifierHS(jwt.HS256, hsKey) },
+		},
+		{
+			func() (jwt.Signer, error) { return jwt.NewSignerRS(jwt.RS256, rsaKey) },
+			func() (jwt.Verifier, error) { return jwt.NewVerifierRS(jwt.RS256, &rsaKey.PublicKey) },
+		},
+		{
+			func() (jwt.Signer, error) { return jwt.NewSignerPS(jwt.PS256, rsaKey) },
+			func() (jwt.Verifier, error) { return jwt.NewVerifierPS(jwt.PS256, &rsaKey.PublicKey) },
+		},
+		{
+			func() (jwt.Signer, error) { return jwt.NewSignerES(jwt.ES256, ecKey) },
+			func() (jwt.Verifier, error) { return jwt.NewVerifierES(jwt.ES256, &ecKey.PublicKey) },
+		},
+		{
+			func() (jwt.Signer, error) { return jwt.NewSignerEdDSA(edKey) },
+			func() (jwt.Verifier, error) { return jwt.NewVerifierEdDSA(edKey.Public().(ed25519.PublicKey)) },
+		},
+	}
+
+	for _, f := range families {
+		signer, signerErr := f.signer()
+		if signerErr != nil {
+			b.Fatal(signerErr)
+		}
+		verifier, verifierErr := f.verifier()
+		if verifierErr != nil {
+			b.Fatal(verifierErr)
+		}
+		builder := jwt.NewBuilder(signer)
+		resolver := func(jwt.Header) (jwt.Verifier, error) { return verifier, nil }
+
+		tokens := make([][]byte, 256)
+		for i := range tokens {
+			token, tokenErr := builder.BuildBytes(jwt.StandardClaims{
+				ID:       "id",
+				Issuer:   "sdf",
+				IssuedAt: jwt.NewNumericDate(time.Now()),
+			})
+			if tokenErr != nil {
+				b.Fatal(tokenErr)
+			}
+			tokens[i] = token
+		}
+
+		b.Run(string(signer.Algorithm()), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				for _, res := range jwt.VerifyBatch(tokens, resolver, 0) {
+					if res.Err != nil {
+						b.Fatal(res.Err)
+					}
+				}
+			}
+		})
+	}
+}
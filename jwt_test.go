@@ -58,10 +58,18 @@ func TestMarshalHeader(t *testing.T) {
 		&Header{Algorithm: RS256, Type: "JwT", ContentType: "token"},
 		`{"alg":"RS256","typ":"JwT","cty":"token"}`,
 	)
+	f(
+		&Header{Algorithm: RS256, Type: "JWT", KeyID: "my-key"},
+		`{"alg":"RS256","typ":"JWT","kid":"my-key"}`,
+	)
+	f(
+		&Header{Algorithm: RS256, Type: `JWT","x":"`, ContentType: "a\\b", KeyID: `x","alg":"none`},
+		`{"alg":"RS256","typ":"JWT\",\"x\":\"","cty":"a\\b","kid":"x\",\"alg\":\"none"}`,
+	)
 }
 
 func TestSecurePrint(t *testing.T) {
-	sign, _ := NewSignerHS(HS256, []byte(`test-key`))
+	sign, _ := NewSignerHS(HS256, []byte(`test-key-0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRS`))
 	claims := &StandardClaims{
 		ID:       "test-id",
 		Audience: Audience([]string{"test-user"}),
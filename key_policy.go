@@ -0,0 +1,88 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+)
+
+// KeyPolicy defines the minimal strength of keys accepted by signers and verifiers.
+// Zero fields mean the defaults, see DefaultKeyPolicy.
+type KeyPolicy struct {
+	// MinHMACKeySize is the minimal HMAC key size in bytes.
+	// By default key must be at least the size of the hash output.
+	// See: https://tools.ietf.org/html/rfc7518#section-3.2
+	MinHMACKeySize int
+
+	// MinRSAKeySize is the minimal RSA modulus size in bits, 2048 by default.
+	// See: https://tools.ietf.org/html/rfc7518#section-3.3
+	MinRSAKeySize int
+
+	// AllowCurveMismatch allows ECDSA keys on any curve.
+	// By default ES256 requires P-256, ES384 requires P-384 and ES512 requires P-521.
+	// Public key is always required to be on its curve.
+	AllowCurveMismatch bool
+}
+
+// DefaultKeyPolicy is used by signers and verifiers if another policy isn't set.
+// Changes affect only signers and verifiers created afterwards.
+var DefaultKeyPolicy = KeyPolicy{}
+
+// InsecureKeyPolicy accepts keys of any strength.
+// Use it only for interoperability with legacy systems.
+var InsecureKeyPolicy = KeyPolicy{
+	MinHMACKeySize:     1,
+	MinRSAKeySize:      1,
+	AllowCurveMismatch: true,
+}
+
+const defaultMinRSAKeySize = 2048
+
+func (p KeyPolicy) checkHMAC(hash crypto.Hash, key []byte) error {
+	min := p.MinHMACKeySize
+	if min == 0 {
+		min = hash.Size()
+	}
+	if len(key) < min {
+		return ErrWeakKey
+	}
+	return nil
+}
+
+func (p KeyPolicy) checkRSA(key *rsa.PublicKey) error {
+	min := p.MinRSAKeySize
+	if min == 0 {
+		min = defaultMinRSAKeySize
+	}
+	if key.N == nil || key.N.BitLen() < min {
+		return ErrWeakKey
+	}
+	return nil
+}
+
+func (p KeyPolicy) checkCurve(alg Algorithm, key *ecdsa.PublicKey) error {
+	if key.Curve == nil || key.X == nil || key.Y == nil {
+		return ErrInvalidKey
+	}
+	if !p.AllowCurveMismatch && key.Curve != getCurveES(alg) {
+		return ErrInvalidCurve
+	}
+	if !key.Curve.IsOnCurve(key.X, key.Y) {
+		return ErrInvalidCurve
+	}
+	return nil
+}
+
+func getCurveES(alg Algorithm) elliptic.Curve {
+	switch alg {
+	case ES256:
+		return elliptic.P256()
+	case ES384:
+		return elliptic.P384()
+	case ES512:
+		return elliptic.P521()
+	default:
+		return nil
+	}
+}
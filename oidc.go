@@ -0,0 +1,260 @@
+package jwt
+
+import (
+	"crypto"
+	"encoding/json"
+	"time"
+
+	"github.com/cloudflare/circl/xof"
+)
+
+// IDTokenClaims represents claims for OpenID Connect ID Token.
+// See: https://openid.net/specs/openid-connect-core-1_0.html#IDToken
+//
+type IDTokenClaims struct {
+	StandardClaims
+
+	// Nonce claim is used to associate a client session with an ID Token.
+	Nonce string `json:"nonce,omitempty"`
+
+	// AuthTime claim is the time when the end-user authentication occurred.
+	AuthTime *NumericDate `json:"auth_time,omitempty"`
+
+	// AuthContextClassRef claim is the authentication context class reference.
+	AuthContextClassRef string `json:"acr,omitempty"`
+
+	// AuthMethodsRef claim is a list of the authentication methods used.
+	AuthMethodsRef []string `json:"amr,omitempty"`
+
+	// AuthorizedParty claim is the party to which the ID Token was issued.
+	AuthorizedParty string `json:"azp,omitempty"`
+
+	// AccessTokenHash claim is the access token hash value.
+	AccessTokenHash string `json:"at_hash,omitempty"`
+
+	// CodeHash claim is the authorization code hash value.
+	CodeHash string `json:"c_hash,omitempty"`
+
+	// SessionID claim is the session identifier.
+	// See: https://openid.net/specs/openid-connect-frontchannel-1_0.html#ClaimsContents
+	SessionID string `json:"sid,omitempty"`
+
+	ProfileClaims
+}
+
+// ProfileClaims represents standard claims about the end-user.
+// See: https://openid.net/specs/openid-connect-core-1_0.html#StandardClaims
+//
+type ProfileClaims struct {
+	Name                string       `json:"name,omitempty"`
+	GivenName           string       `json:"given_name,omitempty"`
+	FamilyName          string       `json:"family_name,omitempty"`
+	MiddleName          string       `json:"middle_name,omitempty"`
+	Nickname            string       `json:"nickname,omitempty"`
+	PreferredUsername   string       `json:"preferred_username,omitempty"`
+	Profile             string       `json:"profile,omitempty"`
+	Picture             string       `json:"picture,omitempty"`
+	Website             string       `json:"website,omitempty"`
+	Email               string       `json:"email,omitempty"`
+	EmailVerified       bool         `json:"email_verified,omitempty"`
+	Gender              string       `json:"gender,omitempty"`
+	Birthdate           string       `json:"birthdate,omitempty"`
+	Zoneinfo            string       `json:"zoneinfo,omitempty"`
+	Locale              string       `json:"locale,omitempty"`
+	PhoneNumber         string       `json:"phone_number,omitempty"`
+	PhoneNumberVerified bool         `json:"phone_number_verified,omitempty"`
+	UpdatedAt           *NumericDate `json:"updated_at,omitempty"`
+}
+
+// IsNonce reports whether token has a given nonce.
+func (c IDTokenClaims) IsNonce(nonce string) bool {
+	return constTimeEqual(c.Nonce, nonce)
+}
+
+// IsValidAuthorizedParty reports whether token was issued to a given client.
+// When token has multiple audiences the azp claim must be present,
+// when azp claim is present it must be equal to the client ID.
+func (c IDTokenClaims) IsValidAuthorizedParty(clientID string) bool {
+	if c.AuthorizedParty == "" {
+		return len(c.Audience) <= 1
+	}
+	return constTimeEqual(c.AuthorizedParty, clientID)
+}
+
+// IsValidAuthTime reports whether end-user authentication isn't older than maxAge at a given time.
+// The auth_time claim is required in this case.
+func (c IDTokenClaims) IsValidAuthTime(now time.Time, maxAge time.Duration) bool {
+	return c.AuthTime != nil && !now.After(c.AuthTime.Add(maxAge))
+}
+
+// IsValidAccessTokenHash reports whether at_hash claim matches a given access token.
+// For EdDSA it assumes Ed25519 key, use IDTokenValidator for Ed448 keys.
+func (c IDTokenClaims) IsValidAccessTokenHash(alg Algorithm, accessToken string) bool {
+	return isValidTokenHash(c.AccessTokenHash, alg, CurveEd25519, accessToken)
+}
+
+// IsValidCodeHash reports whether c_hash claim matches a given authorization code.
+// For EdDSA it assumes Ed25519 key, use IDTokenValidator for Ed448 keys.
+func (c IDTokenClaims) IsValidCodeHash(alg Algorithm, code string) bool {
+	return isValidTokenHash(c.CodeHash, alg, CurveEd25519, code)
+}
+
+// IDTokenParams are the values of the authentication request and response
+// which are checked by IDTokenValidator. Zero values aren't checked.
+//
+type IDTokenParams struct {
+	// Nonce is the nonce sent in the authentication request.
+	Nonce string
+
+	// MaxAge is the max_age sent in the authentication request,
+	// auth_time claim is required if it's set.
+	MaxAge time.Duration
+
+	// AccessToken is the access token issued with the ID Token,
+	// at_hash claim is required if it's set.
+	AccessToken string
+
+	// Code is the authorization code issued with the ID Token,
+	// c_hash claim is required if it's set.
+	Code string
+
+	// Curve is the JWK "crv" of the issuer key for EdDSA tokens, Ed25519 is used if it's empty.
+	Curve string
+}
+
+// IDTokenValidator validates OpenID Connect ID Tokens.
+// See: https://openid.net/specs/openid-connect-core-1_0.html#IDTokenValidation
+//
+type IDTokenValidator struct {
+	issuer   string
+	clientID string
+}
+
+// NewIDTokenValidator returns a new validator for the tokens
+// issued by the given issuer for the given client.
+func NewIDTokenValidator(issuer, clientID string) *IDTokenValidator {
+	return &IDTokenValidator{
+		issuer:   issuer,
+		clientID: clientID,
+	}
+}
+
+// Validate checks the token claims against the OpenID Connect Core rules and returns them.
+// The at_hash and c_hash claims are computed with the hash of the token algorithm.
+// Token signature isn't checked, use ParseAndVerify to obtain the token.
+func (v *IDTokenValidator) Validate(token *Token, now time.Time, params IDTokenParams) (*IDTokenClaims, error) {
+	var claims IDTokenClaims
+	if err := json.Unmarshal(token.RawClaims(), &claims); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case claims.Issuer == "", claims.Subject == "", len(claims.Audience) == 0,
+		claims.ExpiresAt == nil, claims.IssuedAt == nil:
+		return nil, ErrMissingClaim
+	case !claims.IsIssuer(v.issuer):
+		return nil, ErrInvalidIssuer
+	case !claims.IsForAudience(v.clientID), !claims.IsValidAuthorizedParty(v.clientID):
+		return nil, ErrInvalidAudience
+	}
+
+	if err := claims.validateAt(now); err != nil {
+		return nil, err
+	}
+
+	if params.Nonce != "" && !claims.IsNonce(params.Nonce) {
+		return nil, ErrInvalidNonce
+	}
+	if params.MaxAge != 0 {
+		if claims.AuthTime == nil {
+			return nil, ErrMissingClaim
+		}
+		if !claims.IsValidAuthTime(now, params.MaxAge) {
+			return nil, ErrInvalidAuthTime
+		}
+	}
+
+	curve := params.Curve
+	if curve == "" {
+		curve = CurveEd25519
+	}
+	alg := token.Header().Algorithm
+	if params.AccessToken != "" && !isValidTokenHash(claims.AccessTokenHash, alg, curve, params.AccessToken) {
+		return nil, ErrInvalidTokenHash
+	}
+	if params.Code != "" && !isValidTokenHash(claims.CodeHash, alg, curve, params.Code) {
+		return nil, ErrInvalidTokenHash
+	}
+	return &claims, nil
+}
+
+// TokenHash returns a value for at_hash and c_hash claims.
+// It's base64url encoding of the left-most half of the hash of the value,
+// where hash is the one used by the given algorithm.
+// For EdDSA it assumes Ed25519 key, use TokenHashCurve for Ed448 keys.
+func TokenHash(alg Algorithm, value string) (string, error) {
+	return TokenHashCurve(alg, CurveEd25519, value)
+}
+
+// TokenHashCurve is like TokenHash but for EdDSA the hash is selected by the key curve:
+// SHA-512 for Ed25519 and SHAKE256 (114 bytes) for Ed448. The curve is ignored for other algorithms.
+func TokenHashCurve(alg Algorithm, curve, value string) (string, error) {
+	var digest []byte
+	switch {
+	case alg == EdDSA && curve == CurveEd448:
+		digest = make([]byte, 114)
+		shake := xof.SHAKE256.New()
+		if _, err := shake.Write([]byte(value)); err != nil {
+			return "", err
+		}
+		if _, err := shake.Read(digest); err != nil {
+			return "", err
+		}
+
+	case alg == EdDSA && curve != CurveEd25519:
+		return "", ErrUnsupportedAlg
+
+	default:
+		hash, ok := getHashOIDC(alg)
+		if !ok {
+			return "", ErrUnsupportedAlg
+		}
+		var err error
+		digest, err = hashPayload(hash, []byte(value))
+		if err != nil {
+			return "", err
+		}
+	}
+	half := digest[:len(digest)/2]
+
+	encoded := make([]byte, b64EncodedLen(len(half)))
+	b64Encode(encoded, half)
+	return string(encoded), nil
+}
+
+func isValidTokenHash(claim string, alg Algorithm, curve, value string) bool {
+	if claim == "" {
+		return false
+	}
+	hash, err := TokenHashCurve(alg, curve, value)
+	if err != nil {
+		return false
+	}
+	return constTimeEqual(claim, hash)
+}
+
+func getHashOIDC(alg Algorithm) (crypto.Hash, bool) {
+	switch alg {
+	case HS256, RS256, ES256, PS256:
+		return crypto.SHA256, true
+	case HS384, RS384, ES384, PS384:
+		return crypto.SHA384, true
+	case HS512, RS512, ES512, PS512:
+		return crypto.SHA512, true
+	case EdDSA:
+		// Ed25519 uses SHA-512, Ed448 is handled by TokenHashCurve.
+		return crypto.SHA512, true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,265 @@
+package jwt
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestTokenHash(t *testing.T) {
+	f := func(alg Algorithm, value, want string) {
+		t.Helper()
+
+		got, err := TokenHash(alg, value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Errorf("got %#v, want %#v", got, want)
+		}
+	}
+
+	// see: https://openid.net/specs/openid-connect-core-1_0.html#code-id_tokenExample
+	f(RS256, "jHkWEdUXMU1BwAsC4vtUsZwnNvTIxEl0z9K3vx5KF0Y", "77QmUPtjPfzWtF2AnpK9RQ")
+	f(RS256, "Qcb0Orv1zh30vL1MPRsbm-diHiMwcLyZvn1arpZv-Jxf_11jnpEX3Tgfvk", "LDktKdoQak3Pk0cnXxCltA")
+
+	_, err := TokenHash("xxx", "value")
+	if err != ErrUnsupportedAlg {
+		t.Errorf("want %#v, got %#v", ErrUnsupportedAlg, err)
+	}
+}
+
+func TestTokenHashCurve(t *testing.T) {
+	f := func(alg Algorithm, curve, value, want string, wantErr error) {
+		t.Helper()
+
+		got, err := TokenHashCurve(alg, curve, value)
+		if err != wantErr {
+			t.Fatalf("want %#v, got %#v", wantErr, err)
+		}
+		if got != want {
+			t.Errorf("got %#v, want %#v", got, want)
+		}
+	}
+
+	const value = "jHkWEdUXMU1BwAsC4vtUsZwnNvTIxEl0z9K3vx5KF0Y"
+	f(EdDSA, CurveEd25519, value, "q7nS86GgvvFaZkzALLWqJYaJIKw2wCDAVfCAsm5CrBM", nil)
+	f(EdDSA, CurveEd448, value, "W6Ie3EoycJT-JESJ2WSAWX7LRP3FuHvmvNycBMeL-NngYGhJXChp7YRUBdOXcrKGZD4qnhCAstjO", nil)
+	f(EdDSA, CurveP256, value, "", ErrUnsupportedAlg)
+	f(RS256, CurveEd448, value, "77QmUPtjPfzWtF2AnpK9RQ", nil)
+}
+
+func TestIDTokenClaims(t *testing.T) {
+	f := func(claims *IDTokenClaims, f func(claims *IDTokenClaims) bool, want bool) {
+		t.Helper()
+
+		got := f(claims)
+		if got != want {
+			t.Errorf("got %#v, want %#v", got, want)
+		}
+	}
+
+	now := time.Now()
+
+	f(
+		&IDTokenClaims{Nonce: "n-0S6_WzA2Mj"},
+		func(claims *IDTokenClaims) bool { return claims.IsNonce("n-0S6_WzA2Mj") },
+		true,
+	)
+	f(
+		&IDTokenClaims{},
+		func(claims *IDTokenClaims) bool { return claims.IsNonce("n-0S6_WzA2Mj") },
+		false,
+	)
+
+	// IsValidAuthorizedParty
+	f(
+		&IDTokenClaims{StandardClaims: StandardClaims{Audience: Audience{"client"}}},
+		func(claims *IDTokenClaims) bool { return claims.IsValidAuthorizedParty("client") },
+		true,
+	)
+	f(
+		&IDTokenClaims{StandardClaims: StandardClaims{Audience: Audience{"client", "api"}}},
+		func(claims *IDTokenClaims) bool { return claims.IsValidAuthorizedParty("client") },
+		false,
+	)
+	f(
+		&IDTokenClaims{
+			StandardClaims:  StandardClaims{Audience: Audience{"client", "api"}},
+			AuthorizedParty: "client",
+		},
+		func(claims *IDTokenClaims) bool { return claims.IsValidAuthorizedParty("client") },
+		true,
+	)
+	f(
+		&IDTokenClaims{AuthorizedParty: "other"},
+		func(claims *IDTokenClaims) bool { return claims.IsValidAuthorizedParty("client") },
+		false,
+	)
+
+	// IsValidAuthTime
+	f(
+		&IDTokenClaims{},
+		func(claims *IDTokenClaims) bool { return claims.IsValidAuthTime(now, time.Hour) },
+		false,
+	)
+	f(
+		&IDTokenClaims{AuthTime: NewNumericDate(now.Add(-time.Minute))},
+		func(claims *IDTokenClaims) bool { return claims.IsValidAuthTime(now, time.Hour) },
+		true,
+	)
+	f(
+		&IDTokenClaims{AuthTime: NewNumericDate(now.Add(-2 * time.Hour))},
+		func(claims *IDTokenClaims) bool { return claims.IsValidAuthTime(now, time.Hour) },
+		false,
+	)
+
+	// IsValidAccessTokenHash & IsValidCodeHash
+	f(
+		&IDTokenClaims{AccessTokenHash: "77QmUPtjPfzWtF2AnpK9RQ"},
+		func(claims *IDTokenClaims) bool {
+			return claims.IsValidAccessTokenHash(RS256, "jHkWEdUXMU1BwAsC4vtUsZwnNvTIxEl0z9K3vx5KF0Y")
+		},
+		true,
+	)
+	f(
+		&IDTokenClaims{AccessTokenHash: "77QmUPtjPfzWtF2AnpK9RQ"},
+		func(claims *IDTokenClaims) bool {
+			return claims.IsValidAccessTokenHash(RS384, "jHkWEdUXMU1BwAsC4vtUsZwnNvTIxEl0z9K3vx5KF0Y")
+		},
+		false,
+	)
+	f(
+		&IDTokenClaims{},
+		func(claims *IDTokenClaims) bool { return claims.IsValidAccessTokenHash(RS256, "") },
+		false,
+	)
+	f(
+		&IDTokenClaims{CodeHash: "LDktKdoQak3Pk0cnXxCltA"},
+		func(claims *IDTokenClaims) bool {
+			return claims.IsValidCodeHash(RS256, "Qcb0Orv1zh30vL1MPRsbm-diHiMwcLyZvn1arpZv-Jxf_11jnpEX3Tgfvk")
+		},
+		true,
+	)
+}
+
+func TestIDTokenClaimsUnmarshal(t *testing.T) {
+	raw := `{"iss":"https://server.example.com","sub":"248289761001","aud":"s6BhdRkqt3",` +
+		`"nonce":"n-0S6_WzA2Mj","exp":1311281970,"iat":1311280970,"auth_time":1311280969,` +
+		`"acr":"urn:mace:incommon:iap:silver","amr":["pwd","otp"],"azp":"s6BhdRkqt3",` +
+		`"name":"Jane Doe","email":"janedoe@example.com","email_verified":true}`
+
+	var claims IDTokenClaims
+	if err := json.Unmarshal([]byte(raw), &claims); err != nil {
+		t.Fatal(err)
+	}
+
+	if !claims.IsIssuer("https://server.example.com") || !claims.IsForAudience("s6BhdRkqt3") {
+		t.Errorf("unexpected standard claims: %#v", claims.StandardClaims)
+	}
+	if claims.AuthTime == nil || claims.AuthTime.Unix() != 1311280969 {
+		t.Errorf("unexpected auth_time: %v", claims.AuthTime)
+	}
+	if len(claims.AuthMethodsRef) != 2 || claims.AuthContextClassRef != "urn:mace:incommon:iap:silver" {
+		t.Errorf("unexpected acr/amr: %v %v", claims.AuthContextClassRef, claims.AuthMethodsRef)
+	}
+	if claims.Name != "Jane Doe" || !claims.EmailVerified {
+		t.Errorf("unexpected profile claims: %#v", claims.ProfileClaims)
+	}
+}
+
+func TestIDTokenValidator(t *testing.T) {
+	now := time.Now()
+	signer := mustSigner(NewSignerHS(HS256, []byte("id-token-key-0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMN")))
+	validator := NewIDTokenValidator("https://server.example.com", "s6BhdRkqt3")
+
+	validClaims := func() *IDTokenClaims {
+		return &IDTokenClaims{
+			StandardClaims: StandardClaims{
+				Audience:  Audience{"s6BhdRkqt3"},
+				Issuer:    "https://server.example.com",
+				Subject:   "248289761001",
+				ExpiresAt: NewNumericDate(now.Add(time.Hour)),
+				IssuedAt:  NewNumericDate(now.Add(-time.Minute)),
+			},
+			Nonce:           "n-0S6_WzA2Mj",
+			AuthTime:        NewNumericDate(now.Add(-2 * time.Minute)),
+			AccessTokenHash: "77QmUPtjPfzWtF2AnpK9RQ",
+			CodeHash:        "LDktKdoQak3Pk0cnXxCltA",
+		}
+	}
+	validParams := IDTokenParams{
+		Nonce:       "n-0S6_WzA2Mj",
+		MaxAge:      time.Hour,
+		AccessToken: "jHkWEdUXMU1BwAsC4vtUsZwnNvTIxEl0z9K3vx5KF0Y",
+		Code:        "Qcb0Orv1zh30vL1MPRsbm-diHiMwcLyZvn1arpZv-Jxf_11jnpEX3Tgfvk",
+	}
+
+	f := func(claims *IDTokenClaims, params IDTokenParams, want error) {
+		t.Helper()
+
+		token, err := NewBuilder(signer).Build(claims)
+		if err != nil {
+			t.Fatal(err)
+		}
+		token, err = Parse(token.Raw())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := validator.Validate(token, now, params)
+		if err != want {
+			t.Errorf("want %#v, got %#v", want, err)
+		}
+		if err == nil && got.Subject != "248289761001" {
+			t.Errorf("unexpected claims %#v", got)
+		}
+	}
+
+	f(validClaims(), validParams, nil)
+	f(validClaims(), IDTokenParams{}, nil)
+
+	claims := validClaims()
+	claims.Subject = ""
+	f(claims, validParams, ErrMissingClaim)
+
+	claims = validClaims()
+	claims.Issuer = "https://other.example.com"
+	f(claims, validParams, ErrInvalidIssuer)
+
+	claims = validClaims()
+	claims.Audience = Audience{"other"}
+	f(claims, validParams, ErrInvalidAudience)
+
+	// azp is required for multiple audiences
+	claims = validClaims()
+	claims.Audience = Audience{"s6BhdRkqt3", "api"}
+	f(claims, validParams, ErrInvalidAudience)
+	claims.AuthorizedParty = "s6BhdRkqt3"
+	f(claims, validParams, nil)
+
+	claims = validClaims()
+	claims.ExpiresAt = NewNumericDate(now.Add(-time.Minute))
+	f(claims, validParams, ErrTokenExpired)
+
+	claims = validClaims()
+	claims.Nonce = "other"
+	f(claims, validParams, ErrInvalidNonce)
+
+	claims = validClaims()
+	claims.AuthTime = nil
+	f(claims, validParams, ErrMissingClaim)
+	f(claims, IDTokenParams{}, nil)
+
+	claims = validClaims()
+	claims.AuthTime = NewNumericDate(now.Add(-2 * time.Hour))
+	f(claims, validParams, ErrInvalidAuthTime)
+
+	claims = validClaims()
+	claims.AccessTokenHash = ""
+	f(claims, validParams, ErrInvalidTokenHash)
+
+	claims = validClaims()
+	claims.CodeHash = "77QmUPtjPfzWtF2AnpK9RQ"
+	f(claims, validParams, ErrInvalidTokenHash)
+}
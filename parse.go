@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/base64"
 	"encoding/json"
+	"time"
 )
 
 var base64Decode = base64.RawURLEncoding.Decode
@@ -15,53 +16,102 @@ func ParseString(raw string) (*Token, error) {
 
 // Parse decodes a token from a raw bytes.
 func Parse(raw []byte) (*Token, error) {
+	token := &Token{}
+	if err := ParseInto(token, raw); err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+// ParseInto decodes a token from a raw bytes into the given token.
+// Token's buffer is reused, so it doesn't allocate when token is reused
+// for tokens of the same or smaller size and with a common header.
+// Token references raw, previous content of the token is overwritten.
+//
+func ParseInto(token *Token, raw []byte) error {
 	dot1 := bytes.IndexByte(raw, '.')
 	dot2 := bytes.LastIndexByte(raw, '.')
 	if dot2 <= dot1 {
-		return nil, ErrInvalidFormat
+		return ErrInvalidFormat
 	}
 
-	buf := make([]byte, len(raw))
+	buf := token.buf
+	if cap(buf) < len(raw) {
+		buf = make([]byte, len(raw))
+	}
+	buf = buf[:len(raw)]
+	token.buf = buf
 
 	headerN, err := base64Decode(buf, raw[:dot1])
 	if err != nil {
-		return nil, ErrInvalidFormat
+		return ErrInvalidFormat
 	}
-	var header Header
-	if err := json.Unmarshal(buf[:headerN], &header); err != nil {
-		return nil, ErrInvalidFormat
+	if err := token.header.unmarshal(buf[:headerN]); err != nil {
+		return ErrInvalidFormat
 	}
 
 	claimsN, err := base64Decode(buf[headerN:], raw[dot1+1:dot2])
 	if err != nil {
-		return nil, ErrInvalidFormat
+		return ErrInvalidFormat
 	}
 	claims := buf[headerN : headerN+claimsN]
 
 	signN, err := base64Decode(buf[headerN+claimsN:], raw[dot2+1:])
 	if err != nil {
-		return nil, ErrInvalidFormat
+		return ErrInvalidFormat
 	}
 	signature := buf[headerN+claimsN : headerN+claimsN+signN]
 
-	token := &Token{
-		raw:       raw,
-		dot1:      dot1,
-		dot2:      dot2,
-		signature: signature,
-		header:    header,
-		claims:    claims,
+	token.raw = raw
+	token.dot1 = dot1
+	token.dot2 = dot2
+	token.signature = signature
+	token.claims = claims
+	return nil
+}
+
+// ParseOption is used to configure ParseAndVerify.
+type ParseOption func(*parseOptions)
+
+type parseOptions struct {
+	replayStore ReplayStore
+	denyList    DenyList
+	unsafeNone  bool
+}
+
+// WithUnsafeNoneAlgorithm allows unsigned tokens ("none" algorithm) with NewVerifierUnsafeNone.
+// Such tokens aren't protected at all, use it only for tests and local development.
+func WithUnsafeNoneAlgorithm() ParseOption {
+	return func(o *parseOptions) {
+		o.unsafeNone = true
+	}
+}
+
+// WithReplayStore makes tokens single-use, "jti" and "exp" claims are required.
+// Replayed tokens are rejected with ErrTokenReplayed, expired tokens with ErrTokenExpired
+// as their IDs are kept only until expiration.
+func WithReplayStore(store ReplayStore) ParseOption {
+	return func(o *parseOptions) {
+		o.replayStore = store
+	}
+}
+
+// WithDenyList rejects tokens revoked by "jti" claim with ErrTokenRevoked.
+func WithDenyList(list DenyList) ParseOption {
+	return func(o *parseOptions) {
+		o.denyList = list
 	}
-	return token, nil
 }
 
 // ParseAndVerifyString decodes a token and verifies it's signature.
-func ParseAndVerifyString(raw string, verifier Verifier) (*Token, error) {
-	return ParseAndVerify([]byte(raw), verifier)
+func ParseAndVerifyString(raw string, verifier Verifier, opts ...ParseOption) (*Token, error) {
+	return ParseAndVerify([]byte(raw), verifier, opts...)
 }
 
 // ParseAndVerify decodes a token and verifies it's signature.
-func ParseAndVerify(raw []byte, verifier Verifier) (*Token, error) {
+// Token IDs are checked after the signature if a DenyList or a ReplayStore is passed.
+// Unsigned tokens are rejected with ErrUnsafeNone unless WithUnsafeNoneAlgorithm is passed.
+func ParseAndVerify(raw []byte, verifier Verifier, opts ...ParseOption) (*Token, error) {
 	token, err := Parse(raw)
 	if err != nil {
 		return nil, err
@@ -69,8 +119,80 @@ func ParseAndVerify(raw []byte, verifier Verifier) (*Token, error) {
 	if token.Header().Algorithm != verifier.Algorithm() {
 		return nil, ErrAlgorithmMismatch
 	}
-	if err := verifier.Verify(token.Payload(), token.Signature()); err != nil {
+
+	var o *parseOptions
+	if len(opts) != 0 {
+		o = &parseOptions{}
+		for _, opt := range opts {
+			opt(o)
+		}
+	}
+
+	if verifier.Algorithm() == None {
+		if err := o.checkNone(token); err != nil {
+			return nil, err
+		}
+	} else if err := verifyToken(verifier, token); err != nil {
 		return nil, err
 	}
+
+	if o != nil {
+		if err := o.checkID(token); err != nil {
+			return nil, err
+		}
+	}
 	return token, nil
 }
+
+// verifyToken verifies signature of the parsed token.
+func verifyToken(verifier Verifier, token *Token) error {
+	if kv, ok := verifier.(keyIDVerifier); ok {
+		return kv.verifyKeyID(token.Header().KeyID, token.Payload(), token.Signature())
+	}
+	return verifier.Verify(token.Payload(), token.Signature())
+}
+
+func (o *parseOptions) checkNone(token *Token) error {
+	if o == nil || !o.unsafeNone {
+		return ErrUnsafeNone
+	}
+	if len(token.Signature()) != 0 {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+func (o *parseOptions) checkID(token *Token) error {
+	if o.denyList == nil && o.replayStore == nil {
+		return nil
+	}
+
+	var claims StandardClaims
+	if err := json.Unmarshal(token.RawClaims(), &claims); err != nil {
+		return err
+	}
+	if claims.ID == "" {
+		return ErrMissingClaim
+	}
+
+	if o.denyList != nil {
+		revoked, err := o.denyList.IsRevoked(claims.ID)
+		if err != nil {
+			return err
+		}
+		if revoked {
+			return ErrTokenRevoked
+		}
+	}
+
+	if o.replayStore != nil {
+		if claims.ExpiresAt == nil {
+			return ErrMissingClaim
+		}
+		if !claims.IsValidExpiresAt(time.Now()) {
+			return ErrTokenExpired
+		}
+		return o.replayStore.Store(claims.ID, claims.ExpiresAt.Time)
+	}
+	return nil
+}
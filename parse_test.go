@@ -1,6 +1,8 @@
 package jwt
 
 import (
+	"encoding/json"
+	"strings"
 	"testing"
 )
 
@@ -68,6 +70,109 @@ func TestParseMalformed(t *testing.T) {
 	f(`ab_c.xyz.xyz`)
 }
 
+func TestParseInto(t *testing.T) {
+	f := func(raw string, want Header) {
+		t.Helper()
+
+		var tk Token
+		for i := 0; i < 2; i++ {
+			if err := ParseInto(&tk, []byte(raw)); err != nil {
+				t.Fatalf("want nil, got %#v", err)
+			}
+			if tk.Header() != want {
+				t.Errorf("want %#v, got %#v", want, tk.Header())
+			}
+		}
+
+		var jsonHeader Header
+		rawHeader, _ := b64Decode(strings.Split(raw, ".")[0])
+		if err := json.Unmarshal(rawHeader, &jsonHeader); err != nil {
+			t.Fatal(err)
+		}
+		if tk.Header() != jsonHeader {
+			t.Errorf("want %#v, got %#v", jsonHeader, tk.Header())
+		}
+	}
+
+	token := func(header string) string {
+		return toBase64(header) + ".e30.c2lnbg"
+	}
+
+	f(token(`{"alg":"HS256","typ":"JWT"}`), Header{Algorithm: HS256, Type: "JWT"})
+	f(token(` { "alg" : "ES384" , "kid" : "key-1" , "cty":"JWT" } `), Header{Algorithm: ES384, KeyID: "key-1", ContentType: "JWT"})
+	f(token(`{"alg":"none","x":[1,-2.5e+3,{"y":null}],"z":true,"w":"a\"b"}`), Header{Algorithm: "none"})
+	f(token(`{"alg":"HS256","alg":"HS512"}`), Header{Algorithm: HS512})
+	f(token(`{}`), Header{})
+
+	// decoded by encoding/json
+	f(token(`{"ALG":"HS256","Kid":"key"}`), Header{Algorithm: HS256, KeyID: "key"})
+	f(token(`{"alg":"HS256","kid":"k\u0065y"}`), Header{Algorithm: HS256, KeyID: "key"})
+	f(token(`{"alg":"HS256","kid":"ключ"}`), Header{Algorithm: HS256, KeyID: "ключ"})
+	f(token(`{"alg":null,"typ":"JWT"}`), Header{Type: "JWT"})
+}
+
+func TestParseIntoReuse(t *testing.T) {
+	var tk Token
+
+	err := ParseInto(&tk, []byte(toBase64(`{"alg":"HS256","kid":"key-1","typ":"JWT"}`)+".e30.c2lnbg"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ParseInto(&tk, []byte(toBase64(`{"alg":"HS512"}`)+".eyJzdWIiOiJ4In0.c2ln"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (Header{Algorithm: HS512}); tk.Header() != want {
+		t.Errorf("want %#v, got %#v", want, tk.Header())
+	}
+	if string(tk.RawClaims()) != `{"sub":"x"}` {
+		t.Errorf("got %#v", string(tk.RawClaims()))
+	}
+	if string(tk.Signature()) != "sig" {
+		t.Errorf("got %#v", string(tk.Signature()))
+	}
+}
+
+func TestParseIntoMalformed(t *testing.T) {
+	f := func(header string) {
+		t.Helper()
+
+		var tk Token
+		if err := ParseInto(&tk, []byte(toBase64(header)+".e30.c2lnbg")); err != ErrInvalidFormat {
+			t.Errorf("want %#v, got %#v", ErrInvalidFormat, err)
+		}
+	}
+
+	f(`{"alg":"HS256"`)
+	f(`{"alg":"HS256",}`)
+	f(`{"alg":"HS256"}x`)
+	f(`{"alg":1}`)
+	f(`{"x":01}`)
+	f(`{"x":1.}`)
+	f(`{"x":tru}`)
+	f(`{"x":"\q"}`)
+	f(`{"x":[1,]}`)
+	f(`[]`)
+}
+
+func TestParseIntoAllocs(t *testing.T) {
+	raw := []byte(toBase64(`{"alg":"HS256","typ":"JWT","kid":"key-1"}`) +
+		`.eyJqdGkiOiJqdXN0IGFuIGlkIiwiYXVkIjoiYXVkaWVuY2UifQ.t5oEdZGp0Qbth7lo5fZlV_o4-r9gMoYBSktXbarjWoo`)
+
+	var tk Token
+	if err := ParseInto(&tk, raw); err != nil {
+		t.Fatal(err)
+	}
+	allocs := testing.AllocsPerRun(100, func() {
+		if err := ParseInto(&tk, raw); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if allocs != 0 {
+		t.Errorf("want 0 allocs, got %v", allocs)
+	}
+}
+
 func headerString(header Header) string {
 	raw, _ := header.MarshalJSON()
 	return string(raw)
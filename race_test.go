@@ -0,0 +1,7 @@
+//go:build race
+// +build race
+
+package jwt
+
+// raceEnabled reports whether the race detector is on, it makes sync.Pool drop items.
+const raceEnabled = true
@@ -0,0 +1,220 @@
+package jwt
+
+import (
+	"crypto"
+	"sync"
+)
+
+// AlgorithmInfo describes a custom algorithm for RegisterAlgorithm.
+type AlgorithmInfo struct {
+	// Algorithm is a name of the algorithm, it's used as "alg" header.
+	Algorithm Algorithm
+
+	// KeyType and Curve are JWK "kty" and "crv" of the algorithm keys, Curve is optional.
+	KeyType string
+	Curve   string
+
+	// NewSigner returns a signer for the private key, it's used by NewSignerForKey.
+	// Key ID options are applied by NewSignerForKey, other options are passed in opts.
+	NewSigner func(key crypto.PrivateKey, opts *SignerOptions) (Signer, error)
+
+	// NewVerifier returns a verifier for the public key, it's used by NewVerifierForKey.
+	NewVerifier func(key crypto.PublicKey, opts *VerifierOptions) (Verifier, error)
+
+	// PublicKey returns a public key represented by the JWK with the same key type and curve.
+	// It's used by JWK.PublicKey, can be nil.
+	PublicKey func(jwk *JWK) (crypto.PublicKey, error)
+
+	// NewJWK returns a JWK for the public key, ErrInvalidKey is returned for keys of other algorithms.
+	// It's used by NewJWK, can be nil.
+	NewJWK func(key crypto.PublicKey) (*JWK, error)
+
+	// Thumbprint returns JSON of the required JWK members in lexicographic order.
+	// It's used by JWK.Thumbprint with the JWK re-encoded by PublicKey and NewJWK, can be nil.
+	// See: https://tools.ietf.org/html/rfc7638#section-3.2
+	Thumbprint func(jwk *JWK) ([]byte, error)
+}
+
+type registeredAlgorithm struct {
+	info   AlgorithmInfo
+	header string
+}
+
+var algorithms = struct {
+	sync.RWMutex
+	byName map[Algorithm]*registeredAlgorithm
+	list   []*registeredAlgorithm
+}{
+	byName: map[Algorithm]*registeredAlgorithm{},
+}
+
+// RegisterAlgorithm makes a custom algorithm available for key loaders (NewSignerForKey,
+// NewVerifierForKey, NewJWK, JWK.PublicKey and JWK.Thumbprint), Builder and Parse.
+// It's intended to be called from an init function.
+// It panics if the algorithm is already registered or built-in, or the factories are nil.
+//
+func RegisterAlgorithm(info AlgorithmInfo) {
+	if info.Algorithm == "" || info.KeyType == "" || info.NewSigner == nil || info.NewVerifier == nil {
+		panic("jwt: RegisterAlgorithm with incomplete info for " + info.Algorithm.String())
+	}
+	if isBuiltinAlgorithm(info.Algorithm) {
+		panic("jwt: RegisterAlgorithm of built-in algorithm " + info.Algorithm.String())
+	}
+
+	alg := &registeredAlgorithm{
+		info:   info,
+		header: string(encodeHeader(Header{Algorithm: info.Algorithm, Type: "JWT"})),
+	}
+
+	algorithms.Lock()
+	defer algorithms.Unlock()
+
+	if _, ok := algorithms.byName[info.Algorithm]; ok {
+		panic("jwt: RegisterAlgorithm called twice for " + info.Algorithm.String())
+	}
+	algorithms.byName[info.Algorithm] = alg
+	algorithms.list = append(algorithms.list, alg)
+}
+
+// LookupAlgorithm returns info of the registered algorithm.
+func LookupAlgorithm(alg Algorithm) (AlgorithmInfo, bool) {
+	a, ok := lookupAlgorithm(alg)
+	if !ok {
+		return AlgorithmInfo{}, false
+	}
+	return a.info, true
+}
+
+// RegisteredAlgorithms returns names of the registered algorithms in order of registration.
+func RegisteredAlgorithms() []Algorithm {
+	algorithms.RLock()
+	defer algorithms.RUnlock()
+
+	algs := make([]Algorithm, len(algorithms.list))
+	for i, a := range algorithms.list {
+		algs[i] = a.info.Algorithm
+	}
+	return algs
+}
+
+func lookupAlgorithm(alg Algorithm) (*registeredAlgorithm, bool) {
+	algorithms.RLock()
+	a, ok := algorithms.byName[alg]
+	algorithms.RUnlock()
+	return a, ok
+}
+
+// lookupAlgorithmBytes doesn't allocate, it's used by the header parser.
+func lookupAlgorithmBytes(alg []byte) (*registeredAlgorithm, bool) {
+	algorithms.RLock()
+	a, ok := algorithms.byName[Algorithm(alg)]
+	algorithms.RUnlock()
+	return a, ok
+}
+
+func registeredAlgorithms() []*registeredAlgorithm {
+	algorithms.RLock()
+	defer algorithms.RUnlock()
+	return algorithms.list
+}
+
+func isBuiltinAlgorithm(alg Algorithm) bool {
+	return alg == None || getPredefinedHeader(Header{Algorithm: alg, Type: "JWT"}) != ""
+}
+
+func (a *registeredAlgorithm) newSigner(key crypto.PrivateKey, opts []SignerOption) (Signer, error) {
+	o := newSignerOptions(opts)
+
+	// key ID is applied below, so it's not computed twice
+	factoryOpts := *o
+	factoryOpts.keyID, factoryOpts.thumbprintHash = "", 0
+	signer, err := a.info.NewSigner(key, &factoryOpts)
+	if err != nil {
+		return nil, err
+	}
+	if signer == nil || signer.Algorithm() != a.info.Algorithm {
+		return nil, ErrAlgorithmMismatch
+	}
+
+	// thumbprint is computed from the public key
+	var publicKey crypto.PublicKey
+	if o.thumbprintHash != 0 {
+		pk, ok := key.(crypto.Signer)
+		if !ok {
+			return nil, ErrInvalidKey
+		}
+		publicKey = pk.Public()
+	}
+	kid, err := o.getKeyID(publicKey)
+	if err != nil {
+		return nil, err
+	}
+	if kid == "" {
+		return signer, nil
+	}
+	return &registeredSigner{Signer: signer, kid: kid}, nil
+}
+
+// registeredSigner adds a key ID to the signer of a registered algorithm.
+type registeredSigner struct {
+	Signer
+	kid string
+}
+
+func (s *registeredSigner) KeyID() string {
+	return s.kid
+}
+
+func (a *registeredAlgorithm) newVerifier(key crypto.PublicKey, opts []VerifierOption) (Verifier, error) {
+	verifier, err := a.info.NewVerifier(key, newVerifierOptions(opts))
+	if err != nil {
+		return nil, err
+	}
+	if verifier == nil || verifier.Algorithm() != a.info.Algorithm {
+		return nil, ErrAlgorithmMismatch
+	}
+	return verifier, nil
+}
+
+func newRegisteredJWK(key crypto.PublicKey) (*JWK, error) {
+	for _, a := range registeredAlgorithms() {
+		if a.info.NewJWK == nil {
+			continue
+		}
+		if jwk, err := a.info.NewJWK(key); err == nil {
+			return jwk, nil
+		}
+	}
+	return nil, ErrInvalidKey
+}
+
+func registeredPublicKey(jwk *JWK) (crypto.PublicKey, error) {
+	a, ok := selectRegisteredAlgorithm(jwk, func(info *AlgorithmInfo) bool { return info.PublicKey != nil })
+	if !ok {
+		return nil, ErrInvalidKey
+	}
+	return a.info.PublicKey(jwk)
+}
+
+func registeredThumbprint(jwk *JWK) ([]byte, error) {
+	a, ok := selectRegisteredAlgorithm(jwk, func(info *AlgorithmInfo) bool { return info.Thumbprint != nil })
+	if !ok {
+		return nil, ErrInvalidKey
+	}
+	return a.info.Thumbprint(jwk)
+}
+
+// selectRegisteredAlgorithm selects a registered algorithm by JWK key type, curve and algorithm.
+func selectRegisteredAlgorithm(jwk *JWK, filter func(info *AlgorithmInfo) bool) (*registeredAlgorithm, bool) {
+	for _, a := range registeredAlgorithms() {
+		info := &a.info
+		switch {
+		case info.KeyType != jwk.KeyType || !filter(info):
+		case info.Curve != "" && info.Curve != jwk.Curve:
+		case jwk.Algorithm != "" && jwk.Algorithm != info.Algorithm:
+		default:
+			return a, true
+		}
+	}
+	return nil, false
+}
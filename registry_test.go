@@ -0,0 +1,300 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/cloudflare/circl/sign/ed448"
+)
+
+const testAlg Algorithm = "X-TEST"
+
+var registerTestAlg sync.Once
+
+// options passed to the last created signer and verifier of the test algorithm
+var testSignerOptions SignerOptions
+var testVerifierOptions VerifierOptions
+
+func registerTestAlgorithm() {
+	registerTestAlg.Do(func() {
+		RegisterAlgorithm(AlgorithmInfo{
+			Algorithm: testAlg,
+			KeyType:   "TST",
+			NewSigner: func(key crypto.PrivateKey, opts *SignerOptions) (Signer, error) {
+				testSignerOptions = *opts
+				switch k := key.(type) {
+				case *testPrivateKey:
+					return &testAlgo{privateKey: k.key}, nil
+				case testSeed:
+					return &testAlgo{privateKey: ed25519.NewKeyFromSeed(k)}, nil
+				default:
+					return nil, ErrInvalidKey
+				}
+			},
+			NewVerifier: func(key crypto.PublicKey, opts *VerifierOptions) (Verifier, error) {
+				testVerifierOptions = *opts
+				k, ok := key.(testPublicKey)
+				if !ok {
+					return nil, ErrInvalidKey
+				}
+				return &testAlgo{publicKey: ed25519.PublicKey(k)}, nil
+			},
+			PublicKey: func(jwk *JWK) (crypto.PublicKey, error) {
+				x, err := b64Decode(jwk.X)
+				if err != nil || len(x) != ed25519.PublicKeySize {
+					return nil, ErrInvalidKey
+				}
+				return testPublicKey(x), nil
+			},
+			NewJWK: func(key crypto.PublicKey) (*JWK, error) {
+				k, ok := key.(testPublicKey)
+				if !ok {
+					return nil, ErrInvalidKey
+				}
+				return &JWK{KeyType: "TST", Algorithm: testAlg, X: b64EncodeToString(k)}, nil
+			},
+			Thumbprint: func(jwk *JWK) ([]byte, error) {
+				if jwk.X == "" {
+					return nil, ErrInvalidKey
+				}
+				return []byte(`{"kty":"TST","x":"` + jwk.X + `"}`), nil
+			},
+		})
+	})
+}
+
+type testPublicKey []byte
+
+// testSeed is a private key which doesn't implement crypto.Signer.
+type testSeed []byte
+
+type testPrivateKey struct {
+	key ed25519.PrivateKey
+}
+
+func (k *testPrivateKey) Public() crypto.PublicKey {
+	return testPublicKey(k.key.Public().(ed25519.PublicKey))
+}
+
+func (k *testPrivateKey) Sign(random io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	return k.key.Sign(random, digest, opts)
+}
+
+type testAlgo struct {
+	publicKey  ed25519.PublicKey
+	privateKey ed25519.PrivateKey
+}
+
+func (testAlgo) Algorithm() Algorithm { return testAlg }
+
+func (testAlgo) SignSize() int { return ed25519.SignatureSize }
+
+func (a testAlgo) Sign(payload []byte) ([]byte, error) {
+	return ed25519.Sign(a.privateKey, payload), nil
+}
+
+func (a testAlgo) Verify(payload, signature []byte) error {
+	if !ed25519.Verify(a.publicKey, payload, signature) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+func TestRegisterAlgorithm(t *testing.T) {
+	registerTestAlgorithm()
+
+	_, edKey, _ := ed25519.GenerateKey(rand.Reader)
+	key := &testPrivateKey{key: edKey}
+
+	info, ok := LookupAlgorithm(testAlg)
+	if !ok || info.KeyType != "TST" {
+		t.Fatalf("want registered %s, got %#v", testAlg, info)
+	}
+	if _, ok := LookupAlgorithm(HS256); ok {
+		t.Errorf("want %s to be built-in", HS256)
+	}
+	found := false
+	for _, alg := range RegisteredAlgorithms() {
+		found = found || alg == testAlg
+	}
+	if !found {
+		t.Errorf("want %s in registered algorithms", testAlg)
+	}
+
+	signer, err := NewSignerForKey(testAlg, key, WithThumbprintKeyID(crypto.SHA256))
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, err := NewBuilder(signer).Build(&StandardClaims{ID: "just an id"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// JWK is loaded by the registered key type
+	jwk, err := NewJWK(key.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+	kid, err := jwk.ThumbprintKeyID(crypto.SHA256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	publicKey, err := jwk.PublicKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	verifier, err := NewVerifierForKey(testAlg, publicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parsed, err := ParseAndVerify(token.Raw(), verifier)
+	if err != nil {
+		t.Fatal(err)
+	}
+	header := parsed.Header()
+	if header.Algorithm != testAlg || header.Type != "JWT" || header.KeyID != kid {
+		t.Errorf("want %s header with kid %s, got %#v", testAlg, kid, header)
+	}
+
+	f := func(err error, want error) {
+		t.Helper()
+		if err != want {
+			t.Errorf("want %v, got %v", want, err)
+		}
+	}
+	sign := func(_ Signer, err error) error { return err }
+	verify := func(_ Verifier, err error) error { return err }
+	publicKeyErr := func(_ crypto.PublicKey, err error) error { return err }
+
+	f(sign(NewSignerForKey(testAlg, edKey)), ErrInvalidKey)
+	f(verify(NewVerifierForKey(testAlg, edKey.Public())), ErrInvalidKey)
+	f(verify(NewVerifierForKey(EdDSA, publicKey)), ErrInvalidKey)
+	f(publicKeyErr((&JWK{KeyType: "TST", Algorithm: "X-OTHER", X: jwk.X}).PublicKey()), ErrInvalidKey)
+	f(publicKeyErr((&JWK{KeyType: "TST", X: jwk.X}).PublicKey()), nil)
+}
+
+func TestRegisterAlgorithmOptions(t *testing.T) {
+	registerTestAlgorithm()
+
+	_, edKey, _ := ed25519.GenerateKey(rand.Reader)
+	seed := testSeed(edKey.Seed())
+	policy := KeyPolicy{MinRSAKeySize: 4096}
+
+	signer, err := NewSignerForKey(testAlg, seed, WithKeyID("kid"), WithDeterministicSignature(), WithKeyPolicy(policy))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if kid := signer.(keyIDSigner).KeyID(); kid != "kid" {
+		t.Errorf("want kid, got %#v", kid)
+	}
+	if !testSignerOptions.Deterministic || testSignerOptions.Policy != policy || testSignerOptions.Random == nil {
+		t.Errorf("unexpected signer options %#v", testSignerOptions)
+	}
+	if testSignerOptions.keyID != "" {
+		t.Errorf("want key ID applied by NewSignerForKey, got %#v", testSignerOptions.keyID)
+	}
+
+	// thumbprint needs the public key
+	if _, err := NewSignerForKey(testAlg, seed, WithThumbprintKeyID(crypto.SHA256)); err != ErrInvalidKey {
+		t.Errorf("want %v, got %v", ErrInvalidKey, err)
+	}
+
+	if _, err := NewVerifierForKey(testAlg, testPublicKey(edKey.Public().(ed25519.PublicKey)), WithVerifierKeyPolicy(policy)); err != nil {
+		t.Fatal(err)
+	}
+	if testVerifierOptions.Policy != policy {
+		t.Errorf("unexpected verifier options %#v", testVerifierOptions)
+	}
+}
+
+func TestRegisterAlgorithmPanics(t *testing.T) {
+	registerTestAlgorithm()
+
+	f := func(info AlgorithmInfo) {
+		t.Helper()
+		defer func() {
+			if recover() == nil {
+				t.Errorf("want panic for %#v", info.Algorithm)
+			}
+		}()
+		RegisterAlgorithm(info)
+	}
+
+	newSigner := func(crypto.PrivateKey, *SignerOptions) (Signer, error) { return nil, ErrInvalidKey }
+	newVerifier := func(crypto.PublicKey, *VerifierOptions) (Verifier, error) { return nil, ErrInvalidKey }
+
+	f(AlgorithmInfo{Algorithm: "X-EMPTY"})
+	f(AlgorithmInfo{Algorithm: "X-NOKTY", NewSigner: newSigner, NewVerifier: newVerifier})
+	f(AlgorithmInfo{Algorithm: testAlg, KeyType: "TST", NewSigner: newSigner, NewVerifier: newVerifier})
+	f(AlgorithmInfo{Algorithm: HS256, KeyType: KeyTypeOct, NewSigner: newSigner, NewVerifier: newVerifier})
+	f(AlgorithmInfo{Algorithm: None, KeyType: KeyTypeOct, NewSigner: newSigner, NewVerifier: newVerifier})
+}
+
+func TestNewSignerForKey(t *testing.T) {
+	_, edKey, _ := ed25519.GenerateKey(rand.Reader)
+	_, ed448Key, _ := ed448.GenerateKey(rand.Reader)
+	hmacKey := []byte("key-0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWX")
+
+	f := func(alg Algorithm, key crypto.PrivateKey, want error) {
+		t.Helper()
+
+		signer, err := NewSignerForKey(alg, key, WithKeyID("kid"))
+		if err != want {
+			t.Fatalf("%s: want %v, got %v", alg, want, err)
+		}
+		if err != nil {
+			return
+		}
+		if signer.Algorithm() != alg {
+			t.Errorf("want %s, got %s", alg, signer.Algorithm())
+		}
+		if kid := signer.(keyIDSigner).KeyID(); kid != "kid" {
+			t.Errorf("%s: want kid, got %s", alg, kid)
+		}
+	}
+
+	f(HS256, hmacKey, nil)
+	f(RS256, rsaPrivateKey1, nil)
+	f(PS384, rsaPrivateKey1, nil)
+	f(ES256, ecdsaPrivateKey256, nil)
+	f(EdDSA, edKey, nil)
+	f(EdDSA, ed448Key, nil)
+
+	f(ES256, edKey, ErrUnsupportedAlg)
+	f(ES256, ed448Key, ErrUnsupportedAlg)
+	f(EdDSA, rsaPrivateKey1, ErrUnsupportedAlg)
+	f(HS256, "key", ErrInvalidKey)
+}
+
+func TestNewVerifierForKey(t *testing.T) {
+	edKey, _, _ := ed25519.GenerateKey(rand.Reader)
+	ed448Key, _, _ := ed448.GenerateKey(rand.Reader)
+	opt := WithVerifierKeyPolicy(KeyPolicy{MinRSAKeySize: 2048})
+
+	f := func(alg Algorithm, key crypto.PublicKey, want error) {
+		t.Helper()
+
+		verifier, err := NewVerifierForKey(alg, key, opt)
+		if err != want {
+			t.Fatalf("%s: want %v, got %v", alg, want, err)
+		}
+		if err == nil && verifier.Algorithm() != alg {
+			t.Errorf("want %s, got %s", alg, verifier.Algorithm())
+		}
+	}
+
+	f(RS256, rsaPublicKey1, nil)
+	f(PS384, rsaPublicKey1, nil)
+	f(ES256, ecdsaPublicKey256, nil)
+	f(EdDSA, edKey, nil)
+	f(EdDSA, ed448Key, nil)
+
+	f(ES256, edKey, ErrUnsupportedAlg)
+	f(EdDSA, rsaPublicKey1, ErrUnsupportedAlg)
+	f(RS256, "key", ErrInvalidKey)
+}
@@ -0,0 +1,204 @@
+package jwt
+
+import (
+	"hash/fnv"
+	"sync"
+	"time"
+)
+
+// ReplayStore is used to detect replayed token IDs (jti claim).
+type ReplayStore interface {
+	// Store records the given ID until the given time.
+	// Returns ErrTokenReplayed if the ID is already recorded.
+	Store(id string, until time.Time) error
+}
+
+// DenyList is used to revoke tokens by ID (jti claim).
+type DenyList interface {
+	// Revoke denies the given ID until the given time.
+	Revoke(id string, until time.Time) error
+
+	// IsRevoked reports whether the given ID is denied.
+	IsRevoked(id string) (bool, error)
+}
+
+// MemoryReplayStore is an in-memory ReplayStore.
+// IDs are evicted after their time. It is safe for concurrent use.
+type MemoryReplayStore struct {
+	set *expirySet
+}
+
+var _ ReplayStore = (*MemoryReplayStore)(nil)
+
+// NewMemoryReplayStore returns a new MemoryReplayStore.
+func NewMemoryReplayStore() *MemoryReplayStore {
+	return &MemoryReplayStore{set: newExpirySet()}
+}
+
+// Store implements ReplayStore interface.
+func (s *MemoryReplayStore) Store(id string, until time.Time) error {
+	if !s.set.add(id, until) {
+		return ErrTokenReplayed
+	}
+	return nil
+}
+
+// Len returns the number of stored IDs, including expired but not yet evicted.
+func (s *MemoryReplayStore) Len() int {
+	return s.set.len()
+}
+
+// Evict removes IDs expired at the given time.
+// Expired IDs are also evicted periodically on Store.
+func (s *MemoryReplayStore) Evict(now time.Time) {
+	s.set.evict(now)
+}
+
+// MemoryDenyList is an in-memory DenyList.
+// IDs are evicted after their time. It is safe for concurrent use.
+type MemoryDenyList struct {
+	set *expirySet
+}
+
+var _ DenyList = (*MemoryDenyList)(nil)
+
+// NewMemoryDenyList returns a new MemoryDenyList.
+func NewMemoryDenyList() *MemoryDenyList {
+	return &MemoryDenyList{set: newExpirySet()}
+}
+
+// Revoke implements DenyList interface.
+func (l *MemoryDenyList) Revoke(id string, until time.Time) error {
+	l.set.put(id, until)
+	return nil
+}
+
+// IsRevoked implements DenyList interface.
+func (l *MemoryDenyList) IsRevoked(id string) (bool, error) {
+	return l.set.has(id), nil
+}
+
+// Len returns the number of revoked IDs, including expired but not yet evicted.
+func (l *MemoryDenyList) Len() int {
+	return l.set.len()
+}
+
+// Evict removes IDs expired at the given time.
+// Expired IDs are also evicted periodically on Revoke.
+func (l *MemoryDenyList) Evict(now time.Time) {
+	l.set.evict(now)
+}
+
+const (
+	expirySetShards = 32
+
+	// each shard is swept after that many insertions
+	expirySetSweepEvery = 1024
+)
+
+// expirySet is a set of strings with expiration time, sharded to reduce lock contention.
+type expirySet struct {
+	shards [expirySetShards]expiryShard
+	now    func() time.Time
+}
+
+type expiryShard struct {
+	mu      sync.Mutex
+	items   map[string]time.Time
+	inserts int
+}
+
+func newExpirySet() *expirySet {
+	s := &expirySet{now: time.Now}
+	for i := range s.shards {
+		s.shards[i].items = make(map[string]time.Time)
+	}
+	return s
+}
+
+func (s *expirySet) shard(key string) *expiryShard {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return &s.shards[h.Sum32()%expirySetShards]
+}
+
+// add inserts the key, returns false if it's already present and not expired.
+func (s *expirySet) add(key string, until time.Time) bool {
+	now := s.now()
+	sh := s.shard(key)
+
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+
+	if exp, ok := sh.items[key]; ok && now.Before(exp) {
+		return false
+	}
+	sh.insert(key, until, now)
+	return true
+}
+
+// put inserts the key or extends its time.
+func (s *expirySet) put(key string, until time.Time) {
+	now := s.now()
+	sh := s.shard(key)
+
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+
+	if exp, ok := sh.items[key]; ok && exp.After(until) {
+		return
+	}
+	sh.insert(key, until, now)
+}
+
+func (s *expirySet) has(key string) bool {
+	now := s.now()
+	sh := s.shard(key)
+
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+
+	exp, ok := sh.items[key]
+	if ok && !now.Before(exp) {
+		delete(sh.items, key)
+		return false
+	}
+	return ok
+}
+
+func (s *expirySet) len() int {
+	n := 0
+	for i := range s.shards {
+		sh := &s.shards[i]
+		sh.mu.Lock()
+		n += len(sh.items)
+		sh.mu.Unlock()
+	}
+	return n
+}
+
+func (s *expirySet) evict(now time.Time) {
+	for i := range s.shards {
+		sh := &s.shards[i]
+		sh.mu.Lock()
+		sh.sweep(now)
+		sh.mu.Unlock()
+	}
+}
+
+func (sh *expiryShard) insert(key string, until, now time.Time) {
+	sh.items[key] = until
+	sh.inserts++
+	if sh.inserts >= expirySetSweepEvery {
+		sh.sweep(now)
+	}
+}
+
+func (sh *expiryShard) sweep(now time.Time) {
+	for key, exp := range sh.items {
+		if !now.Before(exp) {
+			delete(sh.items, key)
+		}
+	}
+	sh.inserts = 0
+}
@@ -0,0 +1,160 @@
+package jwt
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestMemoryReplayStore(t *testing.T) {
+	now := time.Now()
+	store := NewMemoryReplayStore()
+	store.set.now = func() time.Time { return now }
+
+	f := func(id string, until time.Time, want error) {
+		t.Helper()
+
+		if err := store.Store(id, until); err != want {
+			t.Errorf("want %#v, got %#v", want, err)
+		}
+	}
+
+	f("id-1", now.Add(time.Minute), nil)
+	f("id-1", now.Add(time.Minute), ErrTokenReplayed)
+	f("id-2", now.Add(time.Minute), nil)
+
+	// expired ID can be stored again
+	now = now.Add(2 * time.Minute)
+	f("id-1", now.Add(time.Minute), nil)
+
+	store.Evict(now)
+	if n := store.Len(); n != 1 {
+		t.Errorf("want 1, got %d", n)
+	}
+}
+
+func TestMemoryReplayStoreSweep(t *testing.T) {
+	now := time.Now()
+	store := NewMemoryReplayStore()
+	store.set.now = func() time.Time { return now }
+
+	n := expirySetShards * expirySetSweepEvery
+	for i := 0; i < n; i++ {
+		if err := store.Store(strconv.Itoa(i), now.Add(time.Second)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	now = now.Add(time.Minute)
+	for i := 0; i < n; i++ {
+		if err := store.Store("new-"+strconv.Itoa(i), now.Add(time.Second)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := store.Len(); got >= 2*n {
+		t.Errorf("expired IDs must be evicted, got %d", got)
+	}
+}
+
+func TestMemoryReplayStoreConcurrent(t *testing.T) {
+	store := NewMemoryReplayStore()
+	until := time.Now().Add(time.Minute)
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	stored := 0
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				if store.Store(strconv.Itoa(i), until) == nil {
+					mu.Lock()
+					stored++
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if stored != 1000 {
+		t.Errorf("each ID must be stored once, got %d", stored)
+	}
+}
+
+func TestMemoryDenyList(t *testing.T) {
+	now := time.Now()
+	list := NewMemoryDenyList()
+	list.set.now = func() time.Time { return now }
+
+	f := func(id string, want bool) {
+		t.Helper()
+
+		revoked, err := list.IsRevoked(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if revoked != want {
+			t.Errorf("want %v, got %v", want, revoked)
+		}
+	}
+
+	_ = list.Revoke("id-1", now.Add(time.Minute))
+	_ = list.Revoke("id-2", now.Add(time.Hour))
+	_ = list.Revoke("id-2", now.Add(time.Second))
+
+	f("id-1", true)
+	f("id-2", true)
+	f("id-3", false)
+
+	now = now.Add(2 * time.Minute)
+	f("id-1", false)
+	f("id-2", true)
+
+	list.Evict(now.Add(2 * time.Hour))
+	if n := list.Len(); n != 0 {
+		t.Errorf("want 0, got %d", n)
+	}
+}
+
+func TestParseAndVerifyWithIDChecks(t *testing.T) {
+	signer := mustSigner(NewSignerHS(HS256, []byte("key-0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWX")))
+	verifier := mustVerifier(NewVerifierHS(HS256, []byte("key-0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWX")))
+	exp := NewNumericDate(time.Now().Add(time.Hour))
+
+	f := func(claims *StandardClaims, opts []ParseOption, want error) {
+		t.Helper()
+
+		token, err := Build(signer, claims)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = ParseAndVerify(token.Raw(), verifier, opts...)
+		if err != want {
+			t.Errorf("want %#v, got %#v", want, err)
+		}
+	}
+
+	store := NewMemoryReplayStore()
+	list := NewMemoryDenyList()
+	_ = list.Revoke("revoked", exp.Time)
+
+	f(&StandardClaims{ID: "once", ExpiresAt: exp}, []ParseOption{WithReplayStore(store)}, nil)
+	f(&StandardClaims{ID: "once", ExpiresAt: exp}, []ParseOption{WithReplayStore(store)}, ErrTokenReplayed)
+	f(&StandardClaims{ID: "no-exp"}, []ParseOption{WithReplayStore(store)}, ErrMissingClaim)
+
+	// expired IDs are evicted, so expired tokens aren't stored
+	expired := NewNumericDate(time.Now().Add(-time.Minute))
+	f(&StandardClaims{ID: "expired", ExpiresAt: expired}, []ParseOption{WithReplayStore(store)}, ErrTokenExpired)
+	if store.Len() != 1 {
+		t.Errorf("want 1 stored ID, got %d", store.Len())
+	}
+	f(&StandardClaims{}, []ParseOption{WithDenyList(list)}, ErrMissingClaim)
+
+	f(&StandardClaims{ID: "ok"}, []ParseOption{WithDenyList(list)}, nil)
+	f(&StandardClaims{ID: "revoked"}, []ParseOption{WithDenyList(list)}, ErrTokenRevoked)
+	f(&StandardClaims{ID: "revoked", ExpiresAt: exp}, []ParseOption{WithDenyList(list), WithReplayStore(store)}, ErrTokenRevoked)
+	f(&StandardClaims{}, nil, nil)
+}
@@ -0,0 +1,29 @@
+//go:build go1.24
+// +build go1.24
+
+package jwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"encoding/asn1"
+	"math/big"
+)
+
+// hasRFC6979 reports whether deterministic ECDSA signatures are supported.
+const hasRFC6979 = true
+
+// signRFC6979 returns a deterministic ECDSA signature of the digest,
+// crypto/ecdsa derives the nonce from the private key and the digest with HMAC-DRBG.
+// See: https://tools.ietf.org/html/rfc6979#section-3.2
+func signRFC6979(priv *ecdsa.PrivateKey, hash crypto.Hash, digest []byte) (r, s *big.Int, err error) {
+	signature, err := priv.Sign(nil, digest, hash)
+	if err != nil {
+		return nil, nil, err
+	}
+	var sig struct{ R, S *big.Int }
+	if _, err := asn1.Unmarshal(signature, &sig); err != nil {
+		return nil, nil, err
+	}
+	return sig.R, sig.S, nil
+}
@@ -0,0 +1,18 @@
+//go:build !go1.24
+// +build !go1.24
+
+package jwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"math/big"
+)
+
+// hasRFC6979 reports whether deterministic ECDSA signatures are supported.
+// They're provided by crypto/ecdsa since Go 1.24.
+const hasRFC6979 = false
+
+func signRFC6979(priv *ecdsa.PrivateKey, hash crypto.Hash, digest []byte) (r, s *big.Int, err error) {
+	return nil, nil, ErrUnsupportedAlg
+}
@@ -0,0 +1,157 @@
+package jwt
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"errors"
+	"math/big"
+	"testing"
+)
+
+// See: https://tools.ietf.org/html/rfc6979#appendix-A.2
+func TestRFC6979Vectors(t *testing.T) {
+	if !hasRFC6979 {
+		t.Skip("deterministic ECDSA requires Go 1.24")
+	}
+
+	f := func(alg Algorithm, curve elliptic.Curve, d, msg, r, s string) {
+		t.Helper()
+
+		key := mustKeyEC(curve, d)
+		signer := mustSigner(NewSignerES(alg, key, WithDeterministicSignature()))
+
+		signature, err := signer.Sign([]byte(msg))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		size := signer.SignSize() / 2
+		want := append(padBytes(mustBigInt(r).Bytes(), size), padBytes(mustBigInt(s).Bytes(), size)...)
+		if !bytes.Equal(signature, want) {
+			t.Errorf("%s %q: want %x, got %x", alg, msg, want, signature)
+		}
+
+		verifier := mustVerifier(NewVerifierES(alg, &key.PublicKey))
+		if err := verifier.Verify([]byte(msg), signature); err != nil {
+			t.Errorf("%s %q: want nil, got %v", alg, msg, err)
+		}
+	}
+
+	const (
+		d256 = "C9AFA9D845BA75166B5C215767B1D6934E50C3DB36E89B127B8A622B120F6721"
+		d384 = "6B9D3DAD2E1B8C1C05B19875B6659F4DE23C3B667BF297BA9AA47740787137D896D5724E4C70A825F872C9EA60D2EDF5"
+		d521 = "0FAD06DAA62BA3B25D2FB40133DA757205DE67F5BB0018FEE8C86E1B68C7E75CAA896EB32F1F47C70855836A6D16FCC1466F6D8FBEC67DB89EC0C08B0E996B83538"
+	)
+
+	f(ES256, elliptic.P256(), d256, "sample",
+		"EFD48B2AACB6A8FD1140DD9CD45E81D69D2C877B56AAF991C34D0EA84EAF3716",
+		"F7CB1C942D657C41D436C7A1B6E29F65F3E900DBB9AFF4064DC4AB2F843ACDA8",
+	)
+	f(ES256, elliptic.P256(), d256, "test",
+		"F1ABB023518351CD71D881567B1EA663ED3EFCF6C5132B354F28D3B0B7D38367",
+		"019F4113742A2B14BD25926B49C649155F267E60D3814B4C0CC84250E46F0083",
+	)
+	f(ES384, elliptic.P384(), d384, "sample",
+		"94EDBB92A5ECB8AAD4736E56C691916B3F88140666CE9FA73D64C4EA95AD133C81A648152E44ACF96E36DD1E80FABE46",
+		"99EF4AEB15F178CEA1FE40DB2603138F130E740A19624526203B6351D0A3A94FA329C145786E679E7B82C71A38628AC8",
+	)
+	f(ES512, elliptic.P521(), d521, "sample",
+		"00C328FAFCBD79DD77850370C46325D987CB525569FB63C5D3BC53950E6D4C5F174E25A1EE9017B5D450606ADD152B534931D7D4E8455CC91F9B15BF05EC36E377FA",
+		"00617CCE7CF5064806C467F678D3B4080D6F1CC50AF26CA209417308281B68AF282623EAA63E5B5C0723D8B8C37FF0777B1A20F8CCB1DCCC43997F1EE0E44DA4A67A",
+	)
+}
+
+func TestDeterministicSignature(t *testing.T) {
+	if !hasRFC6979 {
+		if _, err := NewSignerES(ES256, ecdsaPrivateKey256, WithDeterministicSignature()); err != ErrUnsupportedAlg {
+			t.Errorf("want %v, got %v", ErrUnsupportedAlg, err)
+		}
+		t.Skip("deterministic ECDSA requires Go 1.24")
+	}
+
+	claims := &StandardClaims{ID: "just an id"}
+
+	for _, key := range []*ecdsa.PrivateKey{ecdsaPrivateKey256, ecdsaPrivateKey384, ecdsaPrivateKey521} {
+		alg := map[int]Algorithm{256: ES256, 384: ES384, 521: ES512}[key.Params().BitSize]
+
+		// randomness isn't used by deterministic signer
+		signer := mustSigner(NewSignerES(alg, key, WithDeterministicSignature(), WithRandom(errReader{})))
+		verifier := mustVerifier(NewVerifierES(alg, &key.PublicKey))
+		builder := NewBuilder(signer)
+
+		token1, err := builder.Build(claims)
+		if err != nil {
+			t.Fatal(err)
+		}
+		token2, err := builder.Build(claims)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if token1.String() != token2.String() {
+			t.Errorf("%s: want same tokens, got %s and %s", alg, token1, token2)
+		}
+		if _, err := ParseAndVerify(token1.Raw(), verifier); err != nil {
+			t.Errorf("%s: want nil, got %v", alg, err)
+		}
+	}
+}
+
+func TestWithRandom(t *testing.T) {
+	payload := []byte("header.payload")
+
+	signers := []Signer{
+		mustSigner(NewSignerES(ES256, ecdsaPrivateKey256, WithRandom(rand.Reader))),
+		mustSigner(NewSignerRS(RS256, rsaPrivateKey1, WithRandom(rand.Reader))),
+		mustSigner(NewSignerPS(PS256, rsaPrivateKey1, WithRandom(rand.Reader))),
+	}
+	verifiers := []Verifier{
+		mustVerifier(NewVerifierES(ES256, ecdsaPublicKey256)),
+		mustVerifier(NewVerifierRS(RS256, rsaPublicKey1)),
+		mustVerifier(NewVerifierPS(PS256, rsaPublicKey1)),
+	}
+
+	for i, signer := range signers {
+		signature, err := signer.Sign(payload)
+		if err != nil {
+			t.Fatalf("%s: %v", signer.Algorithm(), err)
+		}
+		if err := verifiers[i].Verify(payload, signature); err != nil {
+			t.Errorf("%s: want nil, got %v", signer.Algorithm(), err)
+		}
+	}
+
+	// since Go 1.26 custom readers are used only with this setting
+	t.Setenv("GODEBUG", "cryptocustomrand=1")
+
+	for _, signer := range []Signer{
+		mustSigner(NewSignerES(ES256, ecdsaPrivateKey256, WithRandom(errReader{}))),
+		mustSigner(NewSignerPS(PS256, rsaPrivateKey1, WithRandom(errReader{}))),
+	} {
+		if _, err := signer.Sign(payload); err == nil {
+			t.Errorf("%s: want err, got nil", signer.Algorithm())
+		}
+	}
+}
+
+type errReader struct{}
+
+func (errReader) Read([]byte) (int, error) {
+	return 0, errors.New("no randomness")
+}
+
+func mustKeyEC(curve elliptic.Curve, d string) *ecdsa.PrivateKey {
+	key := &ecdsa.PrivateKey{D: mustBigInt(d)}
+	key.Curve = curve
+	key.X, key.Y = curve.ScalarBaseMult(key.D.Bytes())
+	return key
+}
+
+func mustBigInt(s string) *big.Int {
+	x, ok := new(big.Int).SetString(s, 16)
+	if !ok {
+		panic("invalid hex: " + s)
+	}
+	return x
+}
@@ -0,0 +1,120 @@
+package sdjwt
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+)
+
+var b64 = base64.RawURLEncoding
+
+// Disclosure represents a selectively disclosable claim or array element.
+// See: https://www.rfc-editor.org/rfc/rfc9901.html#section-4.2
+//
+type Disclosure struct {
+	raw   string
+	Salt  string
+	Name  string // empty for array elements
+	Value json.RawMessage
+}
+
+// newDisclosure creates a disclosure with a random salt.
+func newDisclosure(name string, value interface{}) (*Disclosure, error) {
+	var salt [16]byte
+	if _, err := rand.Read(salt[:]); err != nil {
+		return nil, err
+	}
+
+	rawValue, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	d := &Disclosure{
+		Salt:  b64.EncodeToString(salt[:]),
+		Name:  name,
+		Value: rawValue,
+	}
+
+	var arr []interface{}
+	if name == "" {
+		arr = []interface{}{d.Salt, d.Value}
+	} else {
+		arr = []interface{}{d.Salt, d.Name, d.Value}
+	}
+	raw, err := json.Marshal(arr)
+	if err != nil {
+		return nil, err
+	}
+	d.raw = b64.EncodeToString(raw)
+	return d, nil
+}
+
+// ParseDisclosure decodes a disclosure from its base64url encoded form.
+func ParseDisclosure(raw string) (*Disclosure, error) {
+	decoded, err := b64.DecodeString(raw)
+	if err != nil {
+		return nil, ErrInvalidDisclosure
+	}
+
+	var arr []json.RawMessage
+	if err := json.Unmarshal(decoded, &arr); err != nil {
+		return nil, ErrInvalidDisclosure
+	}
+
+	d := &Disclosure{raw: raw}
+	switch len(arr) {
+	case 2:
+		d.Value = arr[1]
+	case 3:
+		if err := json.Unmarshal(arr[1], &d.Name); err != nil {
+			return nil, ErrInvalidDisclosure
+		}
+		if d.Name == "" || d.Name == sdKey || d.Name == arrayElemKey {
+			return nil, ErrInvalidDisclosure
+		}
+		d.Value = arr[2]
+	default:
+		return nil, ErrInvalidDisclosure
+	}
+	if err := json.Unmarshal(arr[0], &d.Salt); err != nil {
+		return nil, ErrInvalidDisclosure
+	}
+	return d, nil
+}
+
+// String returns base64url encoded disclosure.
+func (d *Disclosure) String() string {
+	return d.raw
+}
+
+// IsArrayElement reports whether disclosure is an array element.
+func (d *Disclosure) IsArrayElement() bool {
+	return d.Name == ""
+}
+
+// Digest returns base64url encoded digest of the disclosure.
+func (d *Disclosure) Digest(hash crypto.Hash) string {
+	return digest(hash, d.raw)
+}
+
+func digest(hash crypto.Hash, s string) string {
+	h := hash.New()
+	h.Write([]byte(s))
+	return b64.EncodeToString(h.Sum(nil))
+}
+
+func decodeJSON(raw []byte, v interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	return dec.Decode(v)
+}
+
+func remarshal(from, to interface{}) error {
+	raw, err := json.Marshal(from)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, to)
+}
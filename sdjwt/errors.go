@@ -0,0 +1,21 @@
+package sdjwt
+
+import "github.com/cristalhq/jwt/v3"
+
+// SD-JWT errors.
+const (
+	// ErrInvalidFormat indicates that SD-JWT format is not valid.
+	ErrInvalidFormat = jwt.Error("sdjwt: format is not valid")
+
+	// ErrInvalidDisclosure indicates that disclosure is not valid or isn't referenced by the SD-JWT.
+	ErrInvalidDisclosure = jwt.Error("sdjwt: disclosure is not valid")
+
+	// ErrUnsupportedHash indicates that _sd_alg hash is not supported.
+	ErrUnsupportedHash = jwt.Error("sdjwt: hash algorithm is not supported")
+
+	// ErrKeyBindingRequired indicates that key binding JWT is missing.
+	ErrKeyBindingRequired = jwt.Error("sdjwt: key binding is required")
+
+	// ErrInvalidKeyBinding indicates that key binding JWT is not valid.
+	ErrInvalidKeyBinding = jwt.Error("sdjwt: key binding is not valid")
+)
@@ -0,0 +1,274 @@
+// Package sdjwt implements Selective Disclosure for JWTs (SD-JWT).
+// See: https://www.rfc-editor.org/rfc/rfc9901.html
+package sdjwt
+
+import (
+	"crypto"
+	_ "crypto/sha256" // to register a hash
+	_ "crypto/sha512" // to register a hash
+	"encoding/json"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cristalhq/jwt/v3"
+)
+
+// KeyBindingType is a "typ" header value for Key Binding JWT.
+const KeyBindingType = "kb+jwt"
+
+const (
+	sdKey        = "_sd"
+	sdAlgKey     = "_sd_alg"
+	arrayElemKey = "..."
+	separator    = "~"
+)
+
+// SDJWT represents an issued SD-JWT or a presentation of it.
+type SDJWT struct {
+	Token       *jwt.Token
+	Disclosures []*Disclosure
+	KeyBinding  *jwt.Token
+}
+
+// Parse decodes SD-JWT in the compact format.
+// Signatures and disclosure digests aren't checked, see Verifier.
+func Parse(raw string) (*SDJWT, error) {
+	parts := strings.Split(raw, separator)
+	if len(parts) < 2 {
+		return nil, ErrInvalidFormat
+	}
+
+	token, err := jwt.ParseString(parts[0])
+	if err != nil {
+		return nil, err
+	}
+	s := &SDJWT{Token: token}
+
+	for _, part := range parts[1 : len(parts)-1] {
+		d, err := ParseDisclosure(part)
+		if err != nil {
+			return nil, err
+		}
+		s.Disclosures = append(s.Disclosures, d)
+	}
+
+	if kb := parts[len(parts)-1]; kb != "" {
+		s.KeyBinding, err = jwt.ParseString(kb)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// String returns SD-JWT in the compact format.
+func (s *SDJWT) String() string {
+	str := s.withoutKeyBinding()
+	if s.KeyBinding != nil {
+		str += s.KeyBinding.String()
+	}
+	return str
+}
+
+// Select returns a copy of SD-JWT with disclosures of the given claims only and without key binding.
+// Disclosures of array elements are kept when the claim they belong to is selected.
+func (s *SDJWT) Select(names ...string) (*SDJWT, error) {
+	hash, err := s.hash()
+	if err != nil {
+		return nil, err
+	}
+
+	selected := &SDJWT{Token: s.Token}
+	referenced := make(map[string]bool)
+	for _, d := range s.Disclosures {
+		if !d.IsArrayElement() && contains(names, d.Name) {
+			selected.Disclosures = append(selected.Disclosures, d)
+			collectElemDigests(d.Value, referenced)
+		}
+	}
+
+	// array elements can be nested, repeat until no new disclosures are found
+	for found := true; found; {
+		found = false
+		for _, d := range s.Disclosures {
+			dig := d.Digest(hash)
+			if d.IsArrayElement() && referenced[dig] {
+				selected.Disclosures = append(selected.Disclosures, d)
+				collectElemDigests(d.Value, referenced)
+				delete(referenced, dig)
+				found = true
+			}
+		}
+	}
+	return selected, nil
+}
+
+// collectElemDigests adds digests of array elements found in the value.
+func collectElemDigests(raw json.RawMessage, digests map[string]bool) {
+	var elems []interface{}
+	if err := json.Unmarshal(raw, &elems); err != nil {
+		return
+	}
+	for _, elem := range elems {
+		if dig, ok := arrayElemDigest(elem); ok {
+			digests[dig] = true
+		}
+	}
+}
+
+// Present returns SD-JWT with a key binding JWT signed by holder's key.
+// See: https://www.rfc-editor.org/rfc/rfc9901.html#section-4.3
+func (s *SDJWT) Present(signer jwt.Signer, audience, nonce string, now time.Time) (*SDJWT, error) {
+	hash, err := s.hash()
+	if err != nil {
+		return nil, err
+	}
+
+	claims := &keyBindingClaims{
+		IssuedAt: jwt.NewNumericDate(now),
+		Audience: audience,
+		Nonce:    nonce,
+		SDHash:   digest(hash, s.withoutKeyBinding()),
+	}
+	kb, err := jwt.NewBuilder(signer, jwt.WithType(KeyBindingType)).Build(claims)
+	if err != nil {
+		return nil, err
+	}
+
+	presentation := &SDJWT{
+		Token:       s.Token,
+		Disclosures: s.Disclosures,
+		KeyBinding:  kb,
+	}
+	return presentation, nil
+}
+
+func (s *SDJWT) withoutKeyBinding() string {
+	var b strings.Builder
+	b.WriteString(s.Token.String())
+	b.WriteString(separator)
+	for _, d := range s.Disclosures {
+		b.WriteString(d.String())
+		b.WriteString(separator)
+	}
+	return b.String()
+}
+
+func (s *SDJWT) hash() (crypto.Hash, error) {
+	var claims struct {
+		Alg string `json:"_sd_alg"`
+	}
+	if err := json.Unmarshal(s.Token.RawClaims(), &claims); err != nil {
+		return 0, err
+	}
+	return getHash(claims.Alg)
+}
+
+type keyBindingClaims struct {
+	IssuedAt *jwt.NumericDate `json:"iat"`
+	Audience string           `json:"aud"`
+	Nonce    string           `json:"nonce"`
+	SDHash   string           `json:"sd_hash"`
+}
+
+// Issuer is used to issue SD-JWTs.
+type Issuer struct {
+	builder *jwt.Builder
+}
+
+// NewIssuer returns a new Issuer.
+func NewIssuer(signer jwt.Signer, opts ...jwt.BuilderOption) *Issuer {
+	return &Issuer{
+		builder: jwt.NewBuilder(signer, opts...),
+	}
+}
+
+// Issue returns SD-JWT where the given top-level claims are selectively disclosable.
+// Claims must be marshaled to a JSON object. Elements of disclosable arrays are disclosable too.
+// If holder is not nil it's set as "cnf" claim to enable key binding.
+func (i *Issuer) Issue(claims interface{}, disclosable []string, holder *jwt.JWK) (*SDJWT, error) {
+	raw, err := json.Marshal(claims)
+	if err != nil {
+		return nil, err
+	}
+	var payload map[string]interface{}
+	if err := decodeJSON(raw, &payload); err != nil {
+		return nil, err
+	}
+	if _, ok := payload[sdKey]; ok {
+		return nil, ErrInvalidFormat
+	}
+
+	hash := crypto.SHA256
+	var disclosures []*Disclosure
+	var digests []string
+
+	for _, name := range disclosable {
+		value, ok := payload[name]
+		if !ok {
+			continue
+		}
+		delete(payload, name)
+
+		if arr, ok := value.([]interface{}); ok {
+			elems := make([]interface{}, len(arr))
+			for j, elem := range arr {
+				d, err := newDisclosure("", elem)
+				if err != nil {
+					return nil, err
+				}
+				disclosures = append(disclosures, d)
+				elems[j] = map[string]interface{}{arrayElemKey: d.Digest(hash)}
+			}
+			value = elems
+		}
+
+		d, err := newDisclosure(name, value)
+		if err != nil {
+			return nil, err
+		}
+		disclosures = append(disclosures, d)
+		digests = append(digests, d.Digest(hash))
+	}
+
+	// digests are sorted to hide the original order of claims
+	sort.Strings(digests)
+	if len(digests) > 0 {
+		payload[sdKey] = digests
+	}
+	payload[sdAlgKey], _ = jwt.HashName(hash)
+	if holder != nil {
+		payload["cnf"] = &jwt.Confirmation{JWK: holder}
+	}
+
+	token, err := i.builder.Build(payload)
+	if err != nil {
+		return nil, err
+	}
+	s := &SDJWT{
+		Token:       token,
+		Disclosures: disclosures,
+	}
+	return s, nil
+}
+
+func getHash(name string) (crypto.Hash, error) {
+	if name == "" {
+		return crypto.SHA256, nil
+	}
+	hash, ok := jwt.HashByName(name)
+	if !ok {
+		return 0, ErrUnsupportedHash
+	}
+	return hash, nil
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,199 @@
+package sdjwt
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cristalhq/jwt/v3"
+)
+
+func TestDisclosure(t *testing.T) {
+	// see: https://www.rfc-editor.org/rfc/rfc9901.html#section-4.2.1
+	d, err := ParseDisclosure("WyJfMjZiYzRMVC1hYzZxMktJNmNCVzVlcyIsICJmYW1pbHlfbmFtZSIsICJNw7ZiaXVzIl0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.Salt != "_26bc4LT-ac6q2KI6cBW5es" || d.Name != "family_name" || string(d.Value) != `"Möbius"` {
+		t.Errorf("unexpected disclosure %#v", d)
+	}
+	if got := d.Digest(crypto.SHA256); got != "X9yH0Ajrdm1Oij4tWso9UzzKJvPoDxwmuEcO3XAdRC0" {
+		t.Errorf("unexpected digest %#v", got)
+	}
+}
+
+func TestDisclosureMalformed(t *testing.T) {
+	f := func(s string) {
+		t.Helper()
+
+		if _, err := ParseDisclosure(toBase64(s)); err == nil {
+			t.Errorf("want err for %v", s)
+		}
+	}
+
+	f(`{}`)
+	f(`["salt"]`)
+	f(`["salt","name",1,2]`)
+	f(`[1,"name",1]`)
+	f(`["salt",1,1]`)
+	f(`["salt","_sd",1]`)
+	f(`["salt","...",1]`)
+
+	if _, err := ParseDisclosure("!!!"); err == nil {
+		t.Error("want err")
+	}
+}
+
+type credential struct {
+	Issuer      string   `json:"iss"`
+	GivenName   string   `json:"given_name"`
+	FamilyName  string   `json:"family_name"`
+	Email       string   `json:"email"`
+	Nationality []string `json:"nationalities"`
+}
+
+func TestIssueAndVerify(t *testing.T) {
+	signer, _ := jwt.NewSignerHS(jwt.HS256, []byte("sd-jwt-issuer-key-0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJ"))
+	verifier, _ := jwt.NewVerifierHS(jwt.HS256, []byte("sd-jwt-issuer-key-0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJ"))
+
+	holderPub, holderPriv, _ := ed25519.GenerateKey(rand.Reader)
+	holderSigner, _ := jwt.NewSignerEdDSA(holderPriv)
+	holderJWK, _ := jwt.NewJWK(holderPub)
+
+	issued, err := NewIssuer(signer, jwt.WithType("example+sd-jwt")).Issue(
+		&credential{
+			Issuer:      "https://issuer.example.com",
+			GivenName:   "Erika",
+			FamilyName:  "Mustermann",
+			Email:       "erika@example.com",
+			Nationality: []string{"DE", "FR"},
+		},
+		[]string{"given_name", "family_name", "email", "nationalities"},
+		holderJWK,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issued.Disclosures) != 6 {
+		t.Fatalf("want 6 disclosures, got %d", len(issued.Disclosures))
+	}
+	if strings.Contains(string(issued.Token.RawClaims()), "Erika") {
+		t.Fatalf("claims must be hidden, got %s", issued.Token.RawClaims())
+	}
+
+	// holder receives SD-JWT and discloses only some claims
+	received, err := Parse(issued.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	selected, err := received.Select("given_name", "nationalities")
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	presentation, err := selected.Present(holderSigner, "https://verifier.example.org", "1234567890", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	claims, err := Verify(presentation.String(), verifier, &KeyBinding{
+		Audience: "https://verifier.example.org",
+		Nonce:    "1234567890",
+		Now:      now,
+		MaxAge:   time.Minute,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if claims["iss"] != "https://issuer.example.com" || claims["given_name"] != "Erika" {
+		t.Errorf("unexpected claims %#v", claims)
+	}
+	if _, ok := claims["family_name"]; ok {
+		t.Errorf("claim must not be disclosed %#v", claims)
+	}
+	if _, ok := claims[sdKey]; ok {
+		t.Errorf("_sd must be removed %#v", claims)
+	}
+	raw, _ := json.Marshal(claims["nationalities"])
+	if string(raw) != `["DE","FR"]` {
+		t.Errorf("unexpected nationalities %s", raw)
+	}
+
+	// without key binding
+	claims, err = Verify(issued.String(), verifier, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if claims["family_name"] != "Mustermann" || claims["email"] != "erika@example.com" {
+		t.Errorf("unexpected claims %#v", claims)
+	}
+}
+
+func TestVerifyInvalid(t *testing.T) {
+	signer, _ := jwt.NewSignerHS(jwt.HS256, []byte("sd-jwt-issuer-key-0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJ"))
+	verifier, _ := jwt.NewVerifierHS(jwt.HS256, []byte("sd-jwt-issuer-key-0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJ"))
+
+	holderPub, holderPriv, _ := ed25519.GenerateKey(rand.Reader)
+	holderSigner, _ := jwt.NewSignerEdDSA(holderPriv)
+	holderJWK, _ := jwt.NewJWK(holderPub)
+
+	issued, err := NewIssuer(signer).Issue(
+		map[string]interface{}{"a": 1, "b": 2},
+		[]string{"a", "b"},
+		holderJWK,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	kb := &KeyBinding{Audience: "aud", Nonce: "nonce", Now: now, MaxAge: time.Minute}
+
+	f := func(raw string, kb *KeyBinding, want error) {
+		t.Helper()
+
+		_, err := Verify(raw, verifier, kb)
+		if err != want {
+			t.Errorf("want %#v, got %#v", want, err)
+		}
+	}
+
+	f(issued.String(), kb, ErrKeyBindingRequired)
+
+	presentation, _ := issued.Present(holderSigner, "aud", "nonce", now)
+	f(presentation.String(), kb, nil)
+	f(presentation.String(), &KeyBinding{Audience: "aud", Nonce: "other", Now: now}, ErrInvalidKeyBinding)
+	f(presentation.String(), &KeyBinding{Audience: "other", Nonce: "nonce", Now: now}, ErrInvalidKeyBinding)
+	f(presentation.String(), &KeyBinding{Audience: "aud", Nonce: "nonce", Now: now.Add(time.Hour), MaxAge: time.Minute}, jwt.ErrTokenExpired)
+	f(presentation.String(), &KeyBinding{Audience: "aud", Nonce: "nonce"}, nil)
+
+	// disclosures removed after key binding
+	tampered := &SDJWT{Token: presentation.Token, KeyBinding: presentation.KeyBinding}
+	f(tampered.String(), kb, ErrInvalidKeyBinding)
+
+	// duplicated disclosure
+	dup := &SDJWT{Token: issued.Token, Disclosures: append(issued.Disclosures, issued.Disclosures[0])}
+	f(dup.String(), nil, ErrInvalidDisclosure)
+
+	// disclosure not referenced by the token
+	other, _ := newDisclosure("c", 3)
+	unknown := &SDJWT{Token: issued.Token, Disclosures: append(issued.Disclosures[:1:1], other)}
+	f(unknown.String(), nil, ErrInvalidDisclosure)
+
+	f(issued.Token.String(), nil, ErrInvalidFormat)
+
+	expired, _ := NewIssuer(signer).Issue(map[string]interface{}{"a": 1, "exp": now.Add(-time.Minute).Unix()}, []string{"a"}, nil)
+	f(expired.String(), nil, jwt.ErrTokenExpired)
+
+	notYet, _ := NewIssuer(signer).Issue(map[string]interface{}{"a": 1, "nbf": now.Add(time.Hour).Unix()}, []string{"a"}, nil)
+	f(notYet.String(), nil, jwt.ErrTokenNotYetValid)
+	f(notYet.String(), &KeyBinding{Now: now.Add(2 * time.Hour)}, ErrKeyBindingRequired)
+}
+
+func toBase64(s string) string {
+	return b64.EncodeToString([]byte(s))
+}
@@ -0,0 +1,282 @@
+package sdjwt
+
+import (
+	"crypto"
+	"time"
+
+	"github.com/cristalhq/jwt/v3"
+)
+
+// KeyBinding describes expected key binding JWT of the presentation.
+type KeyBinding struct {
+	// Audience is the expected "aud" claim, an identifier of the verifier.
+	Audience string
+
+	// Nonce is the expected "nonce" claim, a value the verifier provided to the holder.
+	Nonce string
+
+	// Now is the time of verification, the current time is used if it's zero.
+	Now time.Time
+
+	// MaxAge is how long the key binding JWT is accepted after its "iat" claim, no limit if it's zero.
+	MaxAge time.Duration
+}
+
+func (kb *KeyBinding) now() time.Time {
+	if kb == nil || kb.Now.IsZero() {
+		return time.Now()
+	}
+	return kb.Now
+}
+
+// Verify checks SD-JWT signature and disclosures and returns the disclosed claim set.
+// If kb is not nil the key binding JWT is required and checked against the "cnf" claim,
+// otherwise the key binding JWT isn't checked.
+// Expiration and not before claims of SD-JWT are checked at kb.Now or at the current time
+// if kb is nil, other claims are left to the caller.
+func Verify(raw string, verifier jwt.Verifier, kb *KeyBinding) (map[string]interface{}, error) {
+	s, err := Parse(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	token := s.Token
+	if token.Header().Algorithm != verifier.Algorithm() {
+		return nil, jwt.ErrAlgorithmMismatch
+	}
+	if err := verifier.Verify(token.Payload(), token.Signature()); err != nil {
+		return nil, err
+	}
+
+	var std jwt.StandardClaims
+	if err := decodeJSON(token.RawClaims(), &std); err != nil {
+		return nil, err
+	}
+	now := kb.now()
+	if !std.IsValidExpiresAt(now) {
+		return nil, jwt.ErrTokenExpired
+	}
+	if !std.IsValidNotBefore(now) {
+		return nil, jwt.ErrTokenNotYetValid
+	}
+
+	var payload map[string]interface{}
+	if err := decodeJSON(token.RawClaims(), &payload); err != nil {
+		return nil, err
+	}
+	alg, _ := payload[sdAlgKey].(string)
+	hash, err := getHash(alg)
+	if err != nil {
+		return nil, err
+	}
+	delete(payload, sdAlgKey)
+
+	if kb != nil {
+		if err := verifyKeyBinding(s, payload, hash, kb); err != nil {
+			return nil, err
+		}
+	}
+
+	r := &reconstructor{
+		disclosures: make(map[string]*Disclosure, len(s.Disclosures)),
+		seen:        make(map[string]bool),
+	}
+	for _, d := range s.Disclosures {
+		dig := d.Digest(hash)
+		if _, ok := r.disclosures[dig]; ok {
+			return nil, ErrInvalidDisclosure
+		}
+		r.disclosures[dig] = d
+	}
+
+	claims, err := r.object(payload)
+	if err != nil {
+		return nil, err
+	}
+	if r.used != len(r.disclosures) {
+		return nil, ErrInvalidDisclosure
+	}
+	return claims, nil
+}
+
+func verifyKeyBinding(s *SDJWT, payload map[string]interface{}, hash crypto.Hash, kb *KeyBinding) error {
+	if s.KeyBinding == nil {
+		return ErrKeyBindingRequired
+	}
+	header := s.KeyBinding.Header()
+	if header.Type != KeyBindingType {
+		return ErrInvalidKeyBinding
+	}
+
+	cnf, ok := payload["cnf"].(map[string]interface{})
+	if !ok {
+		return ErrInvalidKeyBinding
+	}
+	var jwk jwt.JWK
+	if err := remarshal(cnf["jwk"], &jwk); err != nil {
+		return ErrInvalidKeyBinding
+	}
+	key, err := jwk.PublicKey()
+	if err != nil {
+		return err
+	}
+	verifier, err := jwt.NewVerifierForKey(header.Algorithm, key)
+	if err != nil {
+		return err
+	}
+	if err := verifier.Verify(s.KeyBinding.Payload(), s.KeyBinding.Signature()); err != nil {
+		return err
+	}
+
+	var claims keyBindingClaims
+	if err := decodeJSON(s.KeyBinding.RawClaims(), &claims); err != nil {
+		return err
+	}
+	switch {
+	case claims.IssuedAt == nil,
+		claims.Audience != kb.Audience,
+		claims.Nonce != kb.Nonce,
+		claims.SDHash != digest(hash, s.withoutKeyBinding()):
+		return ErrInvalidKeyBinding
+	case kb.MaxAge > 0 && claims.IssuedAt.Add(kb.MaxAge).Before(kb.now()):
+		return jwt.ErrTokenExpired
+	case claims.IssuedAt.After(kb.now()):
+		return jwt.ErrTokenNotYetValid
+	}
+	return nil
+}
+
+// reconstructor replaces digests with the disclosed values.
+// See: https://www.rfc-editor.org/rfc/rfc9901.html#section-7.1
+type reconstructor struct {
+	disclosures map[string]*Disclosure
+	seen        map[string]bool
+	used        int
+}
+
+func (r *reconstructor) object(obj map[string]interface{}) (map[string]interface{}, error) {
+	res := make(map[string]interface{}, len(obj))
+	for name, value := range obj {
+		if name == sdKey {
+			continue
+		}
+		v, err := r.value(value)
+		if err != nil {
+			return nil, err
+		}
+		res[name] = v
+	}
+
+	sd, ok := obj[sdKey]
+	if !ok {
+		return res, nil
+	}
+	digests, ok := sd.([]interface{})
+	if !ok {
+		return nil, ErrInvalidFormat
+	}
+
+	for _, dig := range digests {
+		d, err := r.use(dig)
+		if err != nil {
+			return nil, err
+		}
+		if d == nil {
+			continue // decoy or not disclosed
+		}
+		if d.IsArrayElement() {
+			return nil, ErrInvalidDisclosure
+		}
+		if _, ok := res[d.Name]; ok {
+			return nil, ErrInvalidDisclosure
+		}
+
+		var value interface{}
+		if err := decodeJSON(d.Value, &value); err != nil {
+			return nil, ErrInvalidDisclosure
+		}
+		v, err := r.value(value)
+		if err != nil {
+			return nil, err
+		}
+		res[d.Name] = v
+	}
+	return res, nil
+}
+
+func (r *reconstructor) array(arr []interface{}) ([]interface{}, error) {
+	res := make([]interface{}, 0, len(arr))
+	for _, elem := range arr {
+		dig, ok := arrayElemDigest(elem)
+		if !ok {
+			v, err := r.value(elem)
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, v)
+			continue
+		}
+
+		d, err := r.use(dig)
+		if err != nil {
+			return nil, err
+		}
+		if d == nil {
+			continue // decoy or not disclosed
+		}
+		if !d.IsArrayElement() {
+			return nil, ErrInvalidDisclosure
+		}
+
+		var value interface{}
+		if err := decodeJSON(d.Value, &value); err != nil {
+			return nil, ErrInvalidDisclosure
+		}
+		v, err := r.value(value)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, v)
+	}
+	return res, nil
+}
+
+func (r *reconstructor) value(value interface{}) (interface{}, error) {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		return r.object(value)
+	case []interface{}:
+		return r.array(value)
+	default:
+		return value, nil
+	}
+}
+
+// use returns disclosure for the digest, nil if there is no such disclosure.
+// Each digest can appear only once.
+func (r *reconstructor) use(dig interface{}) (*Disclosure, error) {
+	s, ok := dig.(string)
+	if !ok {
+		return nil, ErrInvalidFormat
+	}
+	if r.seen[s] {
+		return nil, ErrInvalidDisclosure
+	}
+	r.seen[s] = true
+
+	d, ok := r.disclosures[s]
+	if !ok {
+		return nil, nil
+	}
+	r.used++
+	return d, nil
+}
+
+func arrayElemDigest(elem interface{}) (string, bool) {
+	obj, ok := elem.(map[string]interface{})
+	if !ok || len(obj) != 1 {
+		return "", false
+	}
+	dig, ok := obj[arrayElemKey].(string)
+	return dig, ok
+}
@@ -0,0 +1,166 @@
+package jwt
+
+import (
+	"encoding/json"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// SecurityEventType is a "typ" header value for Security Event Tokens.
+const SecurityEventType = "secevent+jwt"
+
+// SecurityEventClaims represents claims for Security Event Token (SET).
+// See: https://tools.ietf.org/html/rfc8417#section-2.2
+//
+type SecurityEventClaims struct {
+	StandardClaims
+
+	// Events claim is a set of event statements, keyed by event type URI.
+	Events map[string]json.RawMessage `json:"events"`
+
+	// TransactionID claim is used to correlate SETs.
+	TransactionID string `json:"txn,omitempty"`
+
+	// TimeOfEvent claim is the date and time at which the event occurred.
+	TimeOfEvent *NumericDate `json:"toe,omitempty"`
+}
+
+// AddEvent adds event payload for the given event type URI.
+// If payload is of type []byte then it's treated as a marshaled JSON.
+func (c *SecurityEventClaims) AddEvent(uri string, payload interface{}) error {
+	raw, err := encodeClaims(payload)
+	if err != nil {
+		return err
+	}
+	if c.Events == nil {
+		c.Events = make(map[string]json.RawMessage)
+	}
+	c.Events[uri] = raw
+	return nil
+}
+
+// HasEvent reports whether token has an event of the given type URI.
+func (c SecurityEventClaims) HasEvent(uri string) bool {
+	_, ok := c.Events[uri]
+	return ok
+}
+
+// EventRegistry maps event type URIs to Go types of their payloads.
+// It is safe for concurrent use.
+type EventRegistry struct {
+	mu    sync.RWMutex
+	types map[string]reflect.Type
+}
+
+// NewEventRegistry returns a new empty EventRegistry.
+func NewEventRegistry() *EventRegistry {
+	return &EventRegistry{
+		types: make(map[string]reflect.Type),
+	}
+}
+
+// Register sets the payload type for the given event type URI.
+// Payload is a value or a pointer of the type, e.g. SessionRevoked{} or (*SessionRevoked)(nil).
+// It panics if payload is nil, because its type is unknown.
+func (r *EventRegistry) Register(uri string, payload interface{}) {
+	typ := reflect.TypeOf(payload)
+	if typ == nil {
+		panic("jwt: nil payload for event " + uri)
+	}
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	r.mu.Lock()
+	r.types[uri] = typ
+	r.mu.Unlock()
+}
+
+// Decode unmarshals event payloads of the claims.
+// Each registered event is returned as a pointer to its registered type,
+// unregistered events are returned as json.RawMessage.
+func (r *EventRegistry) Decode(claims *SecurityEventClaims) (map[string]interface{}, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	events := make(map[string]interface{}, len(claims.Events))
+	for uri, raw := range claims.Events {
+		typ, ok := r.types[uri]
+		if !ok {
+			events[uri] = raw
+			continue
+		}
+
+		payload := reflect.New(typ).Interface()
+		if err := json.Unmarshal(raw, payload); err != nil {
+			return nil, err
+		}
+		events[uri] = payload
+	}
+	return events, nil
+}
+
+// SecurityEventValidator validates Security Event Tokens.
+// See: https://tools.ietf.org/html/rfc8417#section-2.2
+//
+type SecurityEventValidator struct {
+	issuer         string
+	audience       string
+	allowExpiresAt bool
+}
+
+// SecurityEventOption is used to configure a SecurityEventValidator.
+type SecurityEventOption func(*SecurityEventValidator)
+
+// WithSecurityEventExpiresAt allows "exp" claim in the tokens.
+// By default it's rejected, because SETs describe an event that already happened.
+// When allowed, expired tokens are rejected.
+func WithSecurityEventExpiresAt() SecurityEventOption {
+	return func(v *SecurityEventValidator) {
+		v.allowExpiresAt = true
+	}
+}
+
+// NewSecurityEventValidator returns a new validator for the tokens
+// issued by the given issuer for the given audience.
+// If audience is empty the "aud" claim isn't checked.
+func NewSecurityEventValidator(issuer, audience string, opts ...SecurityEventOption) *SecurityEventValidator {
+	v := &SecurityEventValidator{
+		issuer:   issuer,
+		audience: audience,
+	}
+	for _, opt := range opts {
+		opt(v)
+	}
+	return v
+}
+
+// Validate checks that the token is a valid SET and returns its claims.
+// Token signature isn't checked, use ParseAndVerify to obtain the token.
+func (v *SecurityEventValidator) Validate(token *Token, now time.Time) (*SecurityEventClaims, error) {
+	if !isMediaType(token.Header().Type, SecurityEventType) {
+		return nil, ErrInvalidType
+	}
+
+	var claims SecurityEventClaims
+	if err := json.Unmarshal(token.RawClaims(), &claims); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case claims.Issuer == "", claims.ID == "", claims.IssuedAt == nil, len(claims.Events) == 0:
+		return nil, ErrMissingClaim
+	case claims.ExpiresAt != nil && !v.allowExpiresAt:
+		return nil, ErrUnexpectedClaim
+	case !claims.IsIssuer(v.issuer):
+		return nil, ErrInvalidIssuer
+	case v.audience != "" && !claims.IsForAudience(v.audience):
+		return nil, ErrInvalidAudience
+	}
+
+	if err := claims.validateAt(now); err != nil {
+		return nil, err
+	}
+	return &claims, nil
+}
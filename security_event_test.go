@@ -0,0 +1,147 @@
+package jwt
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+const eventSessionRevoked = "https://schemas.openid.net/secevent/risc/event-type/sessions-revoked"
+
+type sessionRevokedEvent struct {
+	Subject struct {
+		Format string `json:"format"`
+		Email  string `json:"email"`
+	} `json:"subject"`
+}
+
+func TestSecurityEventValidator(t *testing.T) {
+	now := time.Now()
+	signer := mustSigner(NewSignerHS(HS256, []byte("security-event-key-0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHI")))
+
+	validClaims := func() *SecurityEventClaims {
+		claims := &SecurityEventClaims{
+			StandardClaims: StandardClaims{
+				ID:       "756E69717565206964656E746966696572",
+				Audience: Audience{"https://rp.example.com"},
+				Issuer:   "https://idp.example.com",
+				IssuedAt: NewNumericDate(now.Add(-time.Minute)),
+			},
+		}
+		err := claims.AddEvent(eventSessionRevoked, []byte(`{"subject":{"format":"email","email":"foo@bar.baz"}}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return claims
+	}
+
+	f := func(validator *SecurityEventValidator, typ string, claims *SecurityEventClaims, want error) {
+		t.Helper()
+
+		token, err := NewBuilder(signer, WithType(typ)).Build(claims)
+		if err != nil {
+			t.Fatal(err)
+		}
+		token, err = Parse(token.Raw())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := validator.Validate(token, now)
+		if err != want {
+			t.Errorf("want %#v, got %#v", want, err)
+		}
+		if err == nil && !got.HasEvent(eventSessionRevoked) {
+			t.Errorf("unexpected claims %#v", got)
+		}
+	}
+
+	validator := NewSecurityEventValidator("https://idp.example.com", "https://rp.example.com")
+
+	f(validator, SecurityEventType, validClaims(), nil)
+	f(validator, "JWT", validClaims(), ErrInvalidType)
+	f(NewSecurityEventValidator("https://idp.example.com", ""), SecurityEventType, validClaims(), nil)
+
+	c := validClaims()
+	c.Events = nil
+	f(validator, SecurityEventType, c, ErrMissingClaim)
+
+	c = validClaims()
+	c.ID = ""
+	f(validator, SecurityEventType, c, ErrMissingClaim)
+
+	c = validClaims()
+	c.Issuer = "https://evil.example.com"
+	f(validator, SecurityEventType, c, ErrInvalidIssuer)
+
+	c = validClaims()
+	c.Audience = Audience{"https://other.example.com"}
+	f(validator, SecurityEventType, c, ErrInvalidAudience)
+
+	c = validClaims()
+	c.ExpiresAt = NewNumericDate(now.Add(time.Hour))
+	f(validator, SecurityEventType, c, ErrUnexpectedClaim)
+
+	withExp := NewSecurityEventValidator("https://idp.example.com", "", WithSecurityEventExpiresAt())
+	f(withExp, SecurityEventType, c, nil)
+
+	c.ExpiresAt = NewNumericDate(now.Add(-time.Minute))
+	f(withExp, SecurityEventType, c, ErrTokenExpired)
+}
+
+func TestEventRegistry(t *testing.T) {
+	registry := NewEventRegistry()
+	registry.Register(eventSessionRevoked, (*sessionRevokedEvent)(nil))
+
+	claims := &SecurityEventClaims{}
+	event := sessionRevokedEvent{}
+	event.Subject.Format = "email"
+	event.Subject.Email = "foo@bar.baz"
+	if err := claims.AddEvent(eventSessionRevoked, event); err != nil {
+		t.Fatal(err)
+	}
+	if err := claims.AddEvent("urn:example:unknown", map[string]int{"a": 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	raw, err := json.Marshal(claims)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var newClaims SecurityEventClaims
+	if err := json.Unmarshal(raw, &newClaims); err != nil {
+		t.Fatal(err)
+	}
+
+	events, err := registry.Decode(&newClaims)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	revoked, ok := events[eventSessionRevoked].(*sessionRevokedEvent)
+	if !ok {
+		t.Fatalf("want *sessionRevokedEvent, got %T", events[eventSessionRevoked])
+	}
+	if revoked.Subject.Email != "foo@bar.baz" {
+		t.Errorf("unexpected event %#v", revoked)
+	}
+
+	unknown, ok := events["urn:example:unknown"].(json.RawMessage)
+	if !ok || string(unknown) != `{"a":1}` {
+		t.Errorf("unexpected event %#v", events["urn:example:unknown"])
+	}
+
+	newClaims.Events[eventSessionRevoked] = json.RawMessage(`"oops"`)
+	if _, err := registry.Decode(&newClaims); err == nil {
+		t.Error("want err, got nil")
+	}
+}
+
+func TestEventRegistryNilPayload(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("want panic for nil payload")
+		}
+	}()
+	NewEventRegistry().Register(eventSessionRevoked, nil)
+}
@@ -0,0 +1,301 @@
+package jwt
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+// Token use values of SessionClaims.
+const (
+	TokenUseAccess  = "access"
+	TokenUseRefresh = "refresh"
+)
+
+// SessionClaims represents claims of the tokens issued by SessionIssuer.
+type SessionClaims struct {
+	StandardClaims
+
+	// TokenUse claim distinguishes access and refresh tokens.
+	TokenUse string `json:"token_use"`
+
+	// FamilyID claim links all tokens issued for the same session.
+	FamilyID string `json:"fid"`
+
+	// Generation claim is incremented on each refresh token rotation.
+	Generation int `json:"gen"`
+}
+
+// SessionStore keeps the current generation of each token family.
+type SessionStore interface {
+	// Create starts a new family with generation 0 that is kept until the given time.
+	Create(familyID string, until time.Time) error
+
+	// Rotate advances the family from the given generation to the next one and keeps it until the given time.
+	// Returns ErrTokenReused if the generation isn't current and ErrTokenRevoked if the family is revoked.
+	Rotate(familyID string, generation int, until time.Time) error
+
+	// Revoke revokes all tokens of the family.
+	Revoke(familyID string) error
+
+	// IsRevoked reports whether the family is revoked or unknown.
+	IsRevoked(familyID string) (bool, error)
+}
+
+// SessionTokens represents a pair of linked access and refresh tokens.
+type SessionTokens struct {
+	Access   *Token
+	Refresh  *Token
+	FamilyID string
+}
+
+// SessionIssuer issues access and refresh tokens, rotates refresh tokens
+// and revokes the whole family when a superseded refresh token is reused.
+type SessionIssuer struct {
+	builder    *Builder
+	verifier   Verifier
+	store      SessionStore
+	issuer     string
+	accessTTL  time.Duration
+	refreshTTL time.Duration
+}
+
+// SessionOption is used to configure a SessionIssuer.
+type SessionOption func(*SessionIssuer)
+
+// WithSessionIssuer sets "iss" claim of the tokens and checks it on verification.
+func WithSessionIssuer(issuer string) SessionOption {
+	return func(s *SessionIssuer) {
+		s.issuer = issuer
+	}
+}
+
+// WithSessionLifetime sets lifetimes of the tokens, defaults are 15 minutes and 30 days.
+func WithSessionLifetime(access, refresh time.Duration) SessionOption {
+	return func(s *SessionIssuer) {
+		s.accessTTL = access
+		s.refreshTTL = refresh
+	}
+}
+
+// NewSessionIssuer returns a new SessionIssuer.
+// Verifier must correspond to the signer.
+func NewSessionIssuer(signer Signer, verifier Verifier, store SessionStore, opts ...SessionOption) *SessionIssuer {
+	s := &SessionIssuer{
+		builder:    NewBuilder(signer),
+		verifier:   verifier,
+		store:      store,
+		accessTTL:  15 * time.Minute,
+		refreshTTL: 30 * 24 * time.Hour,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Issue starts a new session for the subject.
+func (s *SessionIssuer) Issue(subject string, now time.Time) (*SessionTokens, error) {
+	familyID, err := newRandomID()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.store.Create(familyID, now.Add(s.refreshTTL)); err != nil {
+		return nil, err
+	}
+	return s.build(subject, familyID, 0, now)
+}
+
+// Refresh verifies the refresh token and returns the next pair of tokens.
+// Reuse of a superseded refresh token revokes the whole family and returns ErrTokenReused.
+func (s *SessionIssuer) Refresh(refreshToken []byte, now time.Time) (*SessionTokens, error) {
+	claims, err := s.verify(refreshToken, TokenUseRefresh, now)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.store.Rotate(claims.FamilyID, claims.Generation, now.Add(s.refreshTTL))
+	switch {
+	case err == ErrTokenReused:
+		if errRevoke := s.store.Revoke(claims.FamilyID); errRevoke != nil {
+			return nil, errRevoke
+		}
+		return nil, err
+	case err != nil:
+		return nil, err
+	}
+	return s.build(claims.Subject, claims.FamilyID, claims.Generation+1, now)
+}
+
+// VerifyAccess verifies the access token and checks that its family isn't revoked.
+func (s *SessionIssuer) VerifyAccess(accessToken []byte, now time.Time) (*SessionClaims, error) {
+	claims, err := s.verify(accessToken, TokenUseAccess, now)
+	if err != nil {
+		return nil, err
+	}
+	revoked, err := s.store.IsRevoked(claims.FamilyID)
+	if err != nil {
+		return nil, err
+	}
+	if revoked {
+		return nil, ErrTokenRevoked
+	}
+	return claims, nil
+}
+
+// Revoke revokes all tokens of the family, e.g. on logout.
+func (s *SessionIssuer) Revoke(familyID string) error {
+	return s.store.Revoke(familyID)
+}
+
+func (s *SessionIssuer) build(subject, familyID string, generation int, now time.Time) (*SessionTokens, error) {
+	newClaims := func(use string, ttl time.Duration) (*SessionClaims, error) {
+		id, err := newRandomID()
+		if err != nil {
+			return nil, err
+		}
+		c := &SessionClaims{
+			StandardClaims: StandardClaims{
+				ID:        id,
+				Issuer:    s.issuer,
+				Subject:   subject,
+				ExpiresAt: NewNumericDate(now.Add(ttl)),
+				IssuedAt:  NewNumericDate(now),
+			},
+			TokenUse:   use,
+			FamilyID:   familyID,
+			Generation: generation,
+		}
+		return c, nil
+	}
+
+	accessClaims, err := newClaims(TokenUseAccess, s.accessTTL)
+	if err != nil {
+		return nil, err
+	}
+	access, err := s.builder.Build(accessClaims)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshClaims, err := newClaims(TokenUseRefresh, s.refreshTTL)
+	if err != nil {
+		return nil, err
+	}
+	refresh, err := s.builder.Build(refreshClaims)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens := &SessionTokens{
+		Access:   access,
+		Refresh:  refresh,
+		FamilyID: familyID,
+	}
+	return tokens, nil
+}
+
+func (s *SessionIssuer) verify(raw []byte, use string, now time.Time) (*SessionClaims, error) {
+	token, err := ParseAndVerify(raw, s.verifier)
+	if err != nil {
+		return nil, err
+	}
+
+	var claims SessionClaims
+	if err := json.Unmarshal(token.RawClaims(), &claims); err != nil {
+		return nil, err
+	}
+	switch {
+	case claims.TokenUse != use:
+		return nil, ErrInvalidType
+	case claims.FamilyID == "" || claims.ExpiresAt == nil:
+		return nil, ErrMissingClaim
+	case s.issuer != "" && !claims.IsIssuer(s.issuer):
+		return nil, ErrInvalidIssuer
+	}
+	if err := claims.validateAt(now); err != nil {
+		return nil, err
+	}
+	return &claims, nil
+}
+
+// MemorySessionStore is an in-memory SessionStore.
+// Expired families are removed by Evict. It is safe for concurrent use.
+type MemorySessionStore struct {
+	mu       sync.Mutex
+	families map[string]*tokenFamily
+}
+
+type tokenFamily struct {
+	generation int
+	revoked    bool
+	until      time.Time
+}
+
+var _ SessionStore = (*MemorySessionStore)(nil)
+
+// NewMemorySessionStore returns a new MemorySessionStore.
+func NewMemorySessionStore() *MemorySessionStore {
+	return &MemorySessionStore{
+		families: make(map[string]*tokenFamily),
+	}
+}
+
+// Create implements SessionStore interface.
+func (s *MemorySessionStore) Create(familyID string, until time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.families[familyID] = &tokenFamily{until: until}
+	return nil
+}
+
+// Rotate implements SessionStore interface.
+func (s *MemorySessionStore) Rotate(familyID string, generation int, until time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, ok := s.families[familyID]
+	switch {
+	case !ok || f.revoked:
+		return ErrTokenRevoked
+	case f.generation != generation:
+		return ErrTokenReused
+	}
+	f.generation++
+	f.until = until
+	return nil
+}
+
+// Revoke implements SessionStore interface.
+// Revoked family is kept until its time to detect further reuse.
+func (s *MemorySessionStore) Revoke(familyID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if f, ok := s.families[familyID]; ok {
+		f.revoked = true
+	}
+	return nil
+}
+
+// IsRevoked implements SessionStore interface.
+func (s *MemorySessionStore) IsRevoked(familyID string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, ok := s.families[familyID]
+	return !ok || f.revoked, nil
+}
+
+// Evict removes families expired at the given time.
+func (s *MemorySessionStore) Evict(now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for id, f := range s.families {
+		if !now.Before(f.until) {
+			delete(s.families, id)
+		}
+	}
+}
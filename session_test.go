@@ -0,0 +1,125 @@
+package jwt
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSessionIssuer(t *testing.T) {
+	now := time.Now()
+	key := []byte("session-key-0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOP")
+	store := NewMemorySessionStore()
+	issuer := NewSessionIssuer(
+		mustSigner(NewSignerHS(HS256, key)),
+		mustVerifier(NewVerifierHS(HS256, key)),
+		store,
+		WithSessionIssuer("https://auth.example.com"),
+		WithSessionLifetime(time.Minute, time.Hour),
+	)
+
+	tokens, err := issuer.Issue("user-1", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	claims, err := issuer.VerifyAccess(tokens.Access.Raw(), now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if claims.Subject != "user-1" || claims.FamilyID != tokens.FamilyID || claims.Generation != 0 {
+		t.Errorf("unexpected claims %#v", claims)
+	}
+
+	// refresh token isn't an access token and vice versa
+	if _, err := issuer.VerifyAccess(tokens.Refresh.Raw(), now); err != ErrInvalidType {
+		t.Errorf("want %#v, got %#v", ErrInvalidType, err)
+	}
+	if _, err := issuer.Refresh(tokens.Access.Raw(), now); err != ErrInvalidType {
+		t.Errorf("want %#v, got %#v", ErrInvalidType, err)
+	}
+
+	rotated, err := issuer.Refresh(tokens.Refresh.Raw(), now.Add(time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	claims, err = issuer.VerifyAccess(rotated.Access.Raw(), now.Add(time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if claims.FamilyID != tokens.FamilyID || claims.Generation != 1 {
+		t.Errorf("unexpected claims %#v", claims)
+	}
+
+	// reuse of the superseded refresh token revokes the family
+	if _, err := issuer.Refresh(tokens.Refresh.Raw(), now.Add(time.Minute)); err != ErrTokenReused {
+		t.Errorf("want %#v, got %#v", ErrTokenReused, err)
+	}
+	if _, err := issuer.Refresh(rotated.Refresh.Raw(), now.Add(time.Minute)); err != ErrTokenRevoked {
+		t.Errorf("want %#v, got %#v", ErrTokenRevoked, err)
+	}
+	if _, err := issuer.VerifyAccess(rotated.Access.Raw(), now.Add(time.Minute)); err != ErrTokenRevoked {
+		t.Errorf("want %#v, got %#v", ErrTokenRevoked, err)
+	}
+
+	// another session is not affected
+	other, err := issuer.Issue("user-2", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := issuer.Refresh(other.Refresh.Raw(), now); err != nil {
+		t.Fatal(err)
+	}
+	if err := issuer.Revoke(other.FamilyID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := issuer.VerifyAccess(other.Access.Raw(), now); err != ErrTokenRevoked {
+		t.Errorf("want %#v, got %#v", ErrTokenRevoked, err)
+	}
+
+	store.Evict(now.Add(2 * time.Hour))
+	if n := len(store.families); n != 0 {
+		t.Errorf("want 0, got %d", n)
+	}
+}
+
+func TestSessionIssuerInvalid(t *testing.T) {
+	now := time.Now()
+	key := []byte("session-key-0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOP")
+	issuer := NewSessionIssuer(
+		mustSigner(NewSignerHS(HS256, key)),
+		mustVerifier(NewVerifierHS(HS256, key)),
+		NewMemorySessionStore(),
+		WithSessionIssuer("https://auth.example.com"),
+		WithSessionLifetime(time.Minute, time.Hour),
+	)
+	tokens, err := issuer.Issue("user-1", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := issuer.VerifyAccess(tokens.Access.Raw(), now.Add(time.Hour)); err != ErrTokenExpired {
+		t.Errorf("want %#v, got %#v", ErrTokenExpired, err)
+	}
+	if _, err := issuer.Refresh(tokens.Refresh.Raw(), now.Add(2*time.Hour)); err != ErrTokenExpired {
+		t.Errorf("want %#v, got %#v", ErrTokenExpired, err)
+	}
+
+	otherIssuer := NewSessionIssuer(
+		mustSigner(NewSignerHS(HS256, key)),
+		mustVerifier(NewVerifierHS(HS256, key)),
+		NewMemorySessionStore(),
+		WithSessionIssuer("https://other.example.com"),
+	)
+	if _, err := otherIssuer.Refresh(tokens.Refresh.Raw(), now); err != ErrInvalidIssuer {
+		t.Errorf("want %#v, got %#v", ErrInvalidIssuer, err)
+	}
+
+	// unknown family
+	noIssuer := NewSessionIssuer(
+		mustSigner(NewSignerHS(HS256, key)),
+		mustVerifier(NewVerifierHS(HS256, key)),
+		NewMemorySessionStore(),
+	)
+	if _, err := noIssuer.Refresh(tokens.Refresh.Raw(), now); err != ErrTokenRevoked {
+		t.Errorf("want %#v, got %#v", ErrTokenRevoked, err)
+	}
+}
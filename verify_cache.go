@@ -0,0 +1,152 @@
+package jwt
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+// CachedVerifier is a Verifier that remembers successful verifications.
+// Tokens are cached by a hash of the payload and the signature until their exp claim,
+// tokens without exp claim are always verified. It is safe for concurrent use.
+//
+// Algorithm is the one of the wrapped verifier, so ParseAndVerify checks it as before.
+//
+type CachedVerifier struct {
+	verifier Verifier
+	size     int
+	now      func() time.Time
+
+	mu      sync.Mutex
+	entries map[[sha256.Size]byte]*list.Element
+	lru     *list.List
+}
+
+type cacheEntry struct {
+	key   [sha256.Size]byte
+	until time.Time
+}
+
+var _ Verifier = (*CachedVerifier)(nil)
+
+// NewCachedVerifier returns a new CachedVerifier which keeps at most size tokens.
+func NewCachedVerifier(verifier Verifier, size int) (*CachedVerifier, error) {
+	if verifier == nil || size <= 0 {
+		return nil, ErrInvalidKey
+	}
+	v := &CachedVerifier{
+		verifier: verifier,
+		size:     size,
+		now:      time.Now,
+		entries:  make(map[[sha256.Size]byte]*list.Element, size),
+		lru:      list.New(),
+	}
+	return v, nil
+}
+
+// Algorithm implements Verifier interface.
+func (v *CachedVerifier) Algorithm() Algorithm {
+	return v.verifier.Algorithm()
+}
+
+// Verify implements Verifier interface.
+func (v *CachedVerifier) Verify(payload, signature []byte) error {
+	key := cacheKey(payload, signature)
+	now := v.now()
+	if v.get(key, now) {
+		return nil
+	}
+
+	if err := v.verifier.Verify(payload, signature); err != nil {
+		return err
+	}
+
+	until, ok := expiresAt(payload)
+	if ok && now.Before(until) {
+		v.put(key, until)
+	}
+	return nil
+}
+
+// Len returns the number of cached tokens, including expired but not yet evicted.
+func (v *CachedVerifier) Len() int {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.lru.Len()
+}
+
+// Purge removes all cached tokens.
+func (v *CachedVerifier) Purge() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.entries = make(map[[sha256.Size]byte]*list.Element, v.size)
+	v.lru.Init()
+}
+
+func (v *CachedVerifier) get(key [sha256.Size]byte, now time.Time) bool {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	elem, ok := v.entries[key]
+	if !ok {
+		return false
+	}
+	if !now.Before(elem.Value.(*cacheEntry).until) {
+		v.remove(elem)
+		return false
+	}
+	v.lru.MoveToFront(elem)
+	return true
+}
+
+func (v *CachedVerifier) put(key [sha256.Size]byte, until time.Time) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if elem, ok := v.entries[key]; ok {
+		v.lru.MoveToFront(elem)
+		return
+	}
+	for v.lru.Len() >= v.size {
+		v.remove(v.lru.Back())
+	}
+	v.entries[key] = v.lru.PushFront(&cacheEntry{key: key, until: until})
+}
+
+func (v *CachedVerifier) remove(elem *list.Element) {
+	delete(v.entries, elem.Value.(*cacheEntry).key)
+	v.lru.Remove(elem)
+}
+
+func cacheKey(payload, signature []byte) [sha256.Size]byte {
+	var key [sha256.Size]byte
+	h := sha256.New()
+	h.Write(payload)
+	h.Write([]byte{'.'})
+	h.Write(signature)
+	h.Sum(key[:0])
+	return key
+}
+
+// expiresAt returns exp claim of the payload.
+func expiresAt(payload []byte) (time.Time, bool) {
+	dot := bytes.IndexByte(payload, '.')
+	if dot < 0 {
+		return time.Time{}, false
+	}
+	claims, err := b64Decode(string(payload[dot+1:]))
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	var exp struct {
+		ExpiresAt *NumericDate `json:"exp"`
+	}
+	if err := json.Unmarshal(claims, &exp); err != nil || exp.ExpiresAt == nil {
+		return time.Time{}, false
+	}
+	return exp.ExpiresAt.Time, true
+}
@@ -0,0 +1,171 @@
+package jwt
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+type countingVerifier struct {
+	Verifier
+	mu    sync.Mutex
+	calls int
+}
+
+func (v *countingVerifier) Verify(payload, signature []byte) error {
+	v.mu.Lock()
+	v.calls++
+	v.mu.Unlock()
+	return v.Verifier.Verify(payload, signature)
+}
+
+func TestCachedVerifier(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+	key := []byte("cached-verifier-test-key-32bytes-0123456789abcdefghijklmnopqrstu")
+	signer := mustSigner(NewSignerHS(HS256, key))
+	counter := &countingVerifier{Verifier: mustVerifier(NewVerifierHS(HS256, key))}
+
+	cached, err := NewCachedVerifier(counter, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cached.now = func() time.Time { return now }
+
+	build := func(claims interface{}) *Token {
+		token, err := NewBuilder(signer).Build(claims)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return token
+	}
+	verify := func(token *Token, wantCalls int) {
+		t.Helper()
+
+		if _, err := ParseAndVerify(token.Raw(), cached); err != nil {
+			t.Fatal(err)
+		}
+		if counter.calls != wantCalls {
+			t.Errorf("want %d calls, got %d", wantCalls, counter.calls)
+		}
+	}
+
+	token1 := build(StandardClaims{ID: "1", ExpiresAt: NewNumericDate(now.Add(time.Minute))})
+	verify(token1, 1)
+	verify(token1, 1)
+
+	// no exp claim, never cached
+	token2 := build(StandardClaims{ID: "2"})
+	verify(token2, 2)
+	verify(token2, 3)
+
+	// already expired, not cached
+	token3 := build(StandardClaims{ID: "3", ExpiresAt: NewNumericDate(now.Add(-time.Minute))})
+	verify(token3, 4)
+	verify(token3, 5)
+
+	// least recently used token1 is evicted
+	token4 := build(StandardClaims{ID: "4", ExpiresAt: NewNumericDate(now.Add(time.Minute))})
+	token5 := build(StandardClaims{ID: "5", ExpiresAt: NewNumericDate(now.Add(time.Minute))})
+	verify(token4, 6)
+	verify(token5, 7)
+	if cached.Len() != 2 {
+		t.Errorf("want 2, got %d", cached.Len())
+	}
+	verify(token1, 8)
+	verify(token5, 8)
+
+	// cached until exp
+	now = now.Add(time.Minute)
+	verify(token5, 9)
+
+	cached.Purge()
+	if cached.Len() != 0 {
+		t.Errorf("want 0, got %d", cached.Len())
+	}
+}
+
+func TestCachedVerifierInvalid(t *testing.T) {
+	key := []byte("cached-verifier-test-key-32bytes-0123456789abcdefghijklmnopqrstu")
+	signer := mustSigner(NewSignerHS(HS256, key))
+	otherSigner := mustSigner(NewSignerHS(HS384, key))
+
+	cached, err := NewCachedVerifier(mustVerifier(NewVerifierHS(HS256, key)), 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	claims := StandardClaims{ExpiresAt: NewNumericDate(time.Now().Add(time.Hour))}
+	token, err := NewBuilder(signer).Build(claims)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ParseAndVerify(token.Raw(), cached); err != nil {
+		t.Fatal(err)
+	}
+
+	// bad signature isn't cached
+	bad := append([]byte{}, token.Payload()...)
+	err = cached.Verify(bad, []byte("signature"))
+	if err != ErrInvalidSignature {
+		t.Errorf("want %v, got %v", ErrInvalidSignature, err)
+	}
+
+	// algorithm is checked before the cache
+	other, err := NewBuilder(otherSigner).Build(claims)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ParseAndVerify(other.Raw(), cached); err != ErrAlgorithmMismatch {
+		t.Errorf("want %v, got %v", ErrAlgorithmMismatch, err)
+	}
+	if cached.Len() != 1 {
+		t.Errorf("want 1, got %d", cached.Len())
+	}
+
+	if _, err := NewCachedVerifier(nil, 1); err != ErrInvalidKey {
+		t.Errorf("want %v, got %v", ErrInvalidKey, err)
+	}
+	if _, err := NewCachedVerifier(cached, 0); err != ErrInvalidKey {
+		t.Errorf("want %v, got %v", ErrInvalidKey, err)
+	}
+}
+
+func TestCachedVerifierConcurrent(t *testing.T) {
+	key := []byte("cached-verifier-test-key-32bytes-0123456789abcdefghijklmnopqrstu")
+	signer := mustSigner(NewSignerHS(HS256, key))
+	cached, err := NewCachedVerifier(mustVerifier(NewVerifierHS(HS256, key)), 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tokens := make([]*Token, 8)
+	for i := range tokens {
+		tokens[i], err = NewBuilder(signer).Build(StandardClaims{
+			ID:        string(rune('a' + i)),
+			ExpiresAt: NewNumericDate(time.Now().Add(time.Hour)),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				token := tokens[(i+j)%len(tokens)]
+				if err := cached.Verify(token.Payload(), token.Signature()); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if cached.Len() > 4 {
+		t.Errorf("want at most 4, got %d", cached.Len())
+	}
+}
@@ -0,0 +1,122 @@
+package jwt
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+)
+
+// X509Verifier verifies tokens signed with a key certified by a trusted CA.
+// Certificate chain is taken from "x5c" header of the token.
+// See: https://tools.ietf.org/html/rfc7515#section-4.1.6
+//
+type X509Verifier struct {
+	opts x509.VerifyOptions
+}
+
+// NewX509Verifier returns a new X509Verifier.
+// Options define trusted roots, verification time, required extended key usages and name.
+// Roots are required, system roots aren't used, so publicly issued certificates aren't trusted.
+// If opts.KeyUsages is empty x509.ExtKeyUsageServerAuth is required as in x509.Certificate.Verify.
+//
+func NewX509Verifier(opts x509.VerifyOptions) (*X509Verifier, error) {
+	if opts.Roots == nil {
+		return nil, ErrMissingRoots
+	}
+	return &X509Verifier{
+		opts: opts,
+	}, nil
+}
+
+// ParseAndVerify decodes a token, verifies the certificate chain and the token signature.
+// Returns the token and its leaf certificate.
+func (v *X509Verifier) ParseAndVerify(raw []byte) (*Token, *x509.Certificate, error) {
+	token, err := Parse(raw)
+	if err != nil {
+		return nil, nil, err
+	}
+	cert, err := v.Verify(token)
+	if err != nil {
+		return nil, nil, err
+	}
+	return token, cert, nil
+}
+
+// Verify verifies the certificate chain and the signature of the parsed token.
+// When "x5t#S256" header is present it must match the leaf certificate.
+// Returns the leaf certificate, errors from chain verification are returned as is.
+func (v *X509Verifier) Verify(token *Token) (*x509.Certificate, error) {
+	certs, err := parseCertChain(token)
+	if err != nil {
+		return nil, err
+	}
+	leaf := certs[0]
+
+	opts := v.opts
+	opts.Intermediates = x509.NewCertPool()
+	if v.opts.Intermediates != nil {
+		opts.Intermediates = v.opts.Intermediates.Clone()
+	}
+	for _, cert := range certs[1:] {
+		opts.Intermediates.AddCert(cert)
+	}
+
+	if _, err := leaf.Verify(opts); err != nil {
+		return nil, err
+	}
+	if leaf.KeyUsage != 0 && leaf.KeyUsage&x509.KeyUsageDigitalSignature == 0 {
+		return nil, ErrInvalidCertChain
+	}
+
+	verifier, err := NewVerifierForKey(token.Header().Algorithm, leaf.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifier.Verify(token.Payload(), token.Signature()); err != nil {
+		return nil, err
+	}
+	return leaf, nil
+}
+
+// X509CertThumbprint returns "x5t#S256" value for the certificate.
+func X509CertThumbprint(cert *x509.Certificate) string {
+	digest := sha256.Sum256(cert.Raw)
+	return b64EncodeToString(digest[:])
+}
+
+// parseCertChain returns certificates from "x5c" header, leaf certificate is first.
+func parseCertChain(token *Token) ([]*x509.Certificate, error) {
+	rawHeader, err := base64.RawURLEncoding.DecodeString(string(token.RawHeader()))
+	if err != nil {
+		return nil, ErrInvalidFormat
+	}
+	var header struct {
+		X509CertChain []string `json:"x5c"`
+		X509CertS256  string   `json:"x5t#S256"`
+	}
+	if err := json.Unmarshal(rawHeader, &header); err != nil {
+		return nil, ErrInvalidFormat
+	}
+	if len(header.X509CertChain) == 0 {
+		return nil, ErrInvalidCertChain
+	}
+
+	certs := make([]*x509.Certificate, len(header.X509CertChain))
+	for i, c := range header.X509CertChain {
+		// not base64url, see RFC 7515 section 4.1.6
+		der, err := base64.StdEncoding.DecodeString(c)
+		if err != nil {
+			return nil, ErrInvalidCertChain
+		}
+		certs[i], err = x509.ParseCertificate(der)
+		if err != nil {
+			return nil, ErrInvalidCertChain
+		}
+	}
+
+	if header.X509CertS256 != "" && !constTimeEqual(header.X509CertS256, X509CertThumbprint(certs[0])) {
+		return nil, ErrInvalidCertChain
+	}
+	return certs, nil
+}
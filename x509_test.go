@@ -0,0 +1,172 @@
+package jwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func TestX509Verifier(t *testing.T) {
+	now := time.Now()
+	caKey, ca := newTestCert(t, "Test CA", nil, nil, now, true)
+	interKey, inter := newTestCert(t, "Test Intermediate", ca, caKey, now, true)
+	leafKey, leaf := newTestCert(t, "partner.example.com", inter, interKey, now, false)
+	_, otherCA := newTestCert(t, "Other CA", nil, nil, now, true)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca)
+	clientAuth := []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}
+	verifier := mustX509Verifier(x509.VerifyOptions{Roots: roots, CurrentTime: now, KeyUsages: clientAuth})
+
+	signer := mustSigner(NewSignerES(ES256, leafKey))
+	chain := []*x509.Certificate{leaf, inter}
+
+	f := func(v *X509Verifier, header map[string]interface{}, signer Signer, want error) {
+		t.Helper()
+
+		token := buildWithHeader(t, signer, header)
+		_, cert, err := v.ParseAndVerify(token)
+		if err != want {
+			t.Errorf("want %#v, got %#v", want, err)
+		}
+		if err == nil && cert.Subject.CommonName != "partner.example.com" {
+			t.Errorf("unexpected leaf %v", cert.Subject)
+		}
+	}
+
+	f(verifier, map[string]interface{}{"alg": ES256, "x5c": encodeChain(chain)}, signer, nil)
+	f(verifier, map[string]interface{}{
+		"alg":      ES256,
+		"x5c":      encodeChain(chain),
+		"x5t#S256": X509CertThumbprint(leaf),
+	}, signer, nil)
+
+	f(verifier, map[string]interface{}{"alg": ES256}, signer, ErrInvalidCertChain)
+	f(verifier, map[string]interface{}{"alg": ES256, "x5c": []string{"!!!"}}, signer, ErrInvalidCertChain)
+	f(verifier, map[string]interface{}{
+		"alg":      ES256,
+		"x5c":      encodeChain(chain),
+		"x5t#S256": X509CertThumbprint(inter),
+	}, signer, ErrInvalidCertChain)
+
+	// signed by another key
+	otherSigner := mustSigner(NewSignerES(ES256, ecdsaOtherPrivateKey256))
+	f(verifier, map[string]interface{}{"alg": ES256, "x5c": encodeChain(chain)}, otherSigner, ErrInvalidSignature)
+
+	// symmetric algorithm
+	hsSigner := mustSigner(NewSignerHS(HS256, []byte("key-0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWX")))
+	f(verifier, map[string]interface{}{"alg": HS256, "x5c": encodeChain(chain)}, hsSigner, ErrUnsupportedAlg)
+
+	fErr := func(v *X509Verifier, chain []*x509.Certificate) {
+		t.Helper()
+
+		token := buildWithHeader(t, signer, map[string]interface{}{"alg": ES256, "x5c": encodeChain(chain)})
+		if _, _, err := v.ParseAndVerify(token); err == nil {
+			t.Error("want err, got nil")
+		}
+	}
+
+	// no intermediate
+	fErr(verifier, []*x509.Certificate{leaf})
+
+	// untrusted root
+	otherRoots := x509.NewCertPool()
+	otherRoots.AddCert(otherCA)
+	fErr(mustX509Verifier(x509.VerifyOptions{Roots: otherRoots, CurrentTime: now, KeyUsages: clientAuth}), chain)
+
+	// expired
+	fErr(mustX509Verifier(x509.VerifyOptions{Roots: roots, CurrentTime: now.Add(48 * time.Hour), KeyUsages: clientAuth}), chain)
+
+	// wrong name
+	fErr(mustX509Verifier(x509.VerifyOptions{Roots: roots, CurrentTime: now, DNSName: "other.example.com", KeyUsages: clientAuth}), chain)
+
+	// wrong extended key usage, server auth is required by default
+	fErr(mustX509Verifier(x509.VerifyOptions{
+		Roots:       roots,
+		CurrentTime: now,
+		KeyUsages:   []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}), chain)
+	fErr(mustX509Verifier(x509.VerifyOptions{Roots: roots, CurrentTime: now}), chain)
+
+	// system roots aren't used
+	if _, err := NewX509Verifier(x509.VerifyOptions{CurrentTime: now}); err != ErrMissingRoots {
+		t.Errorf("want %#v, got %#v", ErrMissingRoots, err)
+	}
+}
+
+func mustX509Verifier(opts x509.VerifyOptions) *X509Verifier {
+	v, err := NewX509Verifier(opts)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+func newTestCert(t *testing.T, name string, parent *x509.Certificate, parentKey *ecdsa.PrivateKey, now time.Time, isCA bool) (*ecdsa.PrivateKey, *x509.Certificate) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	serial, _ := rand.Int(rand.Reader, big.NewInt(1<<62))
+	tmpl := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(24 * time.Hour),
+		BasicConstraintsValid: true,
+		IsCA:                  isCA,
+	}
+	if isCA {
+		tmpl.KeyUsage = x509.KeyUsageCertSign
+	} else {
+		tmpl.KeyUsage = x509.KeyUsageDigitalSignature
+		tmpl.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}
+		tmpl.DNSNames = []string{name}
+	}
+	if parent == nil {
+		parent, parentKey = tmpl, key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key, cert
+}
+
+func encodeChain(chain []*x509.Certificate) []string {
+	res := make([]string, len(chain))
+	for i, cert := range chain {
+		res[i] = base64.StdEncoding.EncodeToString(cert.Raw)
+	}
+	return res
+}
+
+func buildWithHeader(t *testing.T, signer Signer, header map[string]interface{}) []byte {
+	t.Helper()
+
+	rawHeader, err := json.Marshal(header)
+	if err != nil {
+		t.Fatal(err)
+	}
+	payload := toBase64(string(rawHeader)) + "." + toBase64(`{"sub":"partner"}`)
+	signature, err := signer.Sign([]byte(payload))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return []byte(payload + "." + toBase64(string(signature)))
+}